@@ -16,6 +16,24 @@ const (
 	AlgoNameHamming           = "Hamming"
 	AlgoNameJaccard           = "Jaccard"
 	AlgoNameWeightedJaccard   = "weighted Jaccard"
+	AlgoNameTokenSort         = "token sort"
+	AlgoNameTokenSet          = "token set"
+	AlgoNamePartial           = "partial"
+	AlgoNameLCS               = "LCS"
+	AlgoNameScaledLCS         = "scaled LCS"
+	AlgoNameLCSubstr          = "longest common substring"
+	AlgoNameScaledLCSubstr    = "scaled " + AlgoNameLCSubstr
+	AlgoNameRatcliffObershelp = "Ratcliff/Obershelp"
+
+	AlgoNameWeightedLevenshtein = "weighted Levenshtein"
+	AlgoNameKeyboard            = "keyboard"
+	AlgoNameSmithWaterman       = "Smith-Waterman"
+	AlgoNameNeedlemanWunsch     = "Needleman-Wunsch"
+	AlgoNameSoundex             = "Soundex"
+	AlgoNameRefinedSoundex      = "refined Soundex"
+	AlgoNameNYSIIS              = "NYSIIS"
+	AlgoNameDoubleMetaphone     = "double Metaphone"
+	AlgoNameEnsemble            = "ensemble"
 
 	caseBlind = "case-blind "
 
@@ -25,17 +43,53 @@ const (
 	CaseBlindAlgoNameHamming           = caseBlind + AlgoNameHamming
 	CaseBlindAlgoNameJaccard           = caseBlind + AlgoNameJaccard
 	CaseBlindAlgoNameWeightedJaccard   = caseBlind + AlgoNameWeightedJaccard
+	CaseBlindAlgoNameTokenSort         = caseBlind + AlgoNameTokenSort
+	CaseBlindAlgoNameTokenSet          = caseBlind + AlgoNameTokenSet
+	CaseBlindAlgoNamePartial           = caseBlind + AlgoNamePartial
+	CaseBlindAlgoNameLCS               = caseBlind + AlgoNameLCS
+	CaseBlindAlgoNameScaledLCS         = caseBlind + AlgoNameScaledLCS
+	CaseBlindAlgoNameLCSubstr          = caseBlind + AlgoNameLCSubstr
+	CaseBlindAlgoNameScaledLCSubstr    = caseBlind + AlgoNameScaledLCSubstr
+	CaseBlindAlgoNameRatcliffObershelp = caseBlind + AlgoNameRatcliffObershelp
+
+	CaseBlindAlgoNameWeightedLevenshtein = caseBlind +
+		AlgoNameWeightedLevenshtein
+	CaseBlindAlgoNameKeyboard        = caseBlind + AlgoNameKeyboard
+	CaseBlindAlgoNameSmithWaterman   = caseBlind + AlgoNameSmithWaterman
+	CaseBlindAlgoNameNeedlemanWunsch = caseBlind + AlgoNameNeedlemanWunsch
+	CaseBlindAlgoNameSoundex         = caseBlind + AlgoNameSoundex
+	CaseBlindAlgoNameRefinedSoundex  = caseBlind + AlgoNameRefinedSoundex
+	CaseBlindAlgoNameNYSIIS          = caseBlind + AlgoNameNYSIIS
+	CaseBlindAlgoNameDoubleMetaphone = caseBlind + AlgoNameDoubleMetaphone
+	CaseBlindAlgoNameEnsemble        = caseBlind + AlgoNameEnsemble
 )
 
 // DfltThreshold consts are suggested default similarity thresholds for the
 // Finder when using the corresponding distance algorithms
 const (
-	DfltThresholdLevenshtein     = 5.0
-	DfltThresholdScaledLev       = 0.33
-	DfltThresholdCosine          = 0.4
-	DfltThresholdHamming         = 5.0
-	DfltThresholdJaccard         = 0.5
-	DfltThresholdWeightedJaccard = 0.7
+	DfltThresholdLevenshtein       = 5.0
+	DfltThresholdScaledLev         = 0.33
+	DfltThresholdCosine            = 0.4
+	DfltThresholdHamming           = 5.0
+	DfltThresholdJaccard           = 0.5
+	DfltThresholdWeightedJaccard   = 0.7
+	DfltThresholdTokenSort         = DfltThresholdScaledLev
+	DfltThresholdTokenSet          = DfltThresholdScaledLev
+	DfltThresholdPartial           = DfltThresholdScaledLev
+	DfltThresholdLCS               = 5.0
+	DfltThresholdScaledLCS         = 0.4
+	DfltThresholdLCSubstr          = 5.0
+	DfltThresholdScaledLCSubstr    = 0.5
+	DfltThresholdRatcliffObershelp = 0.4
+	DfltThresholdWeightedLev       = DfltThresholdLevenshtein
+	DfltThresholdKeyboard          = 3.0
+	DfltThresholdSmithWaterman     = 0.3
+	DfltThresholdNeedlemanWunsch   = 0.4
+	DfltThresholdSoundex           = 0.0
+	DfltThresholdRefinedSoundex    = 0.0
+	DfltThresholdNYSIIS            = 0.0
+	DfltThresholdDoubleMetaphone   = 0.0
+	DfltThresholdEnsemble          = 0.35
 )
 
 // DefaultThresholds associates the default similarity thresholds with the
@@ -47,6 +101,24 @@ var DefaultThresholds = map[string]float64{
 	AlgoNameHamming:           DfltThresholdHamming,
 	AlgoNameJaccard:           DfltThresholdJaccard,
 	AlgoNameWeightedJaccard:   DfltThresholdWeightedJaccard,
+	AlgoNameTokenSort:         DfltThresholdTokenSort,
+	AlgoNameTokenSet:          DfltThresholdTokenSet,
+	AlgoNamePartial:           DfltThresholdPartial,
+	AlgoNameLCS:               DfltThresholdLCS,
+	AlgoNameScaledLCS:         DfltThresholdScaledLCS,
+	AlgoNameLCSubstr:          DfltThresholdLCSubstr,
+	AlgoNameScaledLCSubstr:    DfltThresholdScaledLCSubstr,
+	AlgoNameRatcliffObershelp: DfltThresholdRatcliffObershelp,
+
+	AlgoNameWeightedLevenshtein: DfltThresholdWeightedLev,
+	AlgoNameKeyboard:            DfltThresholdKeyboard,
+	AlgoNameSmithWaterman:       DfltThresholdSmithWaterman,
+	AlgoNameNeedlemanWunsch:     DfltThresholdNeedlemanWunsch,
+	AlgoNameSoundex:             DfltThresholdSoundex,
+	AlgoNameRefinedSoundex:      DfltThresholdRefinedSoundex,
+	AlgoNameNYSIIS:              DfltThresholdNYSIIS,
+	AlgoNameDoubleMetaphone:     DfltThresholdDoubleMetaphone,
+	AlgoNameEnsemble:            DfltThresholdEnsemble,
 }
 
 // DefaultFinders associates the Finders with the algorithm name
@@ -87,6 +159,108 @@ var DefaultFinders = map[string]*Finder{
 			MinStrLength: DfltMinStrLength,
 		},
 		NewWeightedJaccardAlgoOrPanic(DfltNGramConfig, DfltMaxCacheSize)),
+	AlgoNameTokenSort: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdTokenSort,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewTokenSortAlgo(nil, nil)),
+	AlgoNameTokenSet: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdTokenSet,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewTokenSetAlgo(nil, nil)),
+	AlgoNamePartial: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdPartial,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewPartialAlgo(nil)),
+	AlgoNameLCS: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdLCS,
+			MinStrLength: DfltMinStrLength,
+		},
+		LCSAlgo{}),
+	AlgoNameScaledLCS: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdScaledLCS,
+			MinStrLength: DfltMinStrLength,
+		},
+		ScaledLCSAlgo{}),
+	AlgoNameLCSubstr: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdLCSubstr,
+			MinStrLength: DfltMinStrLength,
+		},
+		LongestCommonSubstringAlgo{}),
+	AlgoNameScaledLCSubstr: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdScaledLCSubstr,
+			MinStrLength: DfltMinStrLength,
+		},
+		ScaledLongestCommonSubstringAlgo{}),
+	AlgoNameRatcliffObershelp: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdRatcliffObershelp,
+			MinStrLength: DfltMinStrLength,
+		},
+		RatcliffObershelpAlgo{AutoJunk: true}),
+	AlgoNameWeightedLevenshtein: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdWeightedLev,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewWeightedLevenshteinAlgoOrPanic(DamerauLevenshteinCosts)),
+	AlgoNameKeyboard: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdKeyboard,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewKeyboardAlgoOrPanic(NewKeyboardCostModel(KeyboardQWERTY))),
+	AlgoNameSmithWaterman: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdSmithWaterman,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewSmithWatermanAlgoOrPanic(DfltAlignScores)),
+	AlgoNameNeedlemanWunsch: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdNeedlemanWunsch,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewNeedlemanWunschAlgoOrPanic(DfltAlignScores)),
+	AlgoNameSoundex: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdSoundex,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewPhoneticAlgo(SoundexEncoder{}, nil)),
+	AlgoNameRefinedSoundex: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdRefinedSoundex,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewPhoneticAlgo(RefinedSoundexEncoder{}, nil)),
+	AlgoNameNYSIIS: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdNYSIIS,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewPhoneticAlgo(NYSIISEncoder{}, nil)),
+	AlgoNameDoubleMetaphone: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdDoubleMetaphone,
+			MinStrLength: DfltMinStrLength,
+		},
+		NewMetaphoneAlgo(nil)),
+	AlgoNameEnsemble: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:    DfltThresholdEnsemble,
+			MinStrLength: DfltMinStrLength,
+		},
+		dfltEnsembleAlgo()),
 
 	CaseBlindAlgoNameLevenshtein: NewFinderOrPanic(
 		FinderConfig{
@@ -130,4 +304,135 @@ var DefaultFinders = map[string]*Finder{
 			MinStrLength:   DfltMinStrLength,
 		},
 		NewWeightedJaccardAlgoOrPanic(DfltNGramConfig, DfltMaxCacheSize)),
+	CaseBlindAlgoNameTokenSort: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdTokenSort,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewTokenSortAlgo(nil, nil)),
+	CaseBlindAlgoNameTokenSet: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdTokenSet,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewTokenSetAlgo(nil, nil)),
+	CaseBlindAlgoNamePartial: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdPartial,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewPartialAlgo(nil)),
+	CaseBlindAlgoNameLCS: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdLCS,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		LCSAlgo{}),
+	CaseBlindAlgoNameScaledLCS: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdScaledLCS,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		ScaledLCSAlgo{}),
+	CaseBlindAlgoNameLCSubstr: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdLCSubstr,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		LongestCommonSubstringAlgo{}),
+	CaseBlindAlgoNameScaledLCSubstr: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdScaledLCSubstr,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		ScaledLongestCommonSubstringAlgo{}),
+	CaseBlindAlgoNameRatcliffObershelp: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdRatcliffObershelp,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		RatcliffObershelpAlgo{AutoJunk: true}),
+	CaseBlindAlgoNameWeightedLevenshtein: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdWeightedLev,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewWeightedLevenshteinAlgoOrPanic(DamerauLevenshteinCosts)),
+	CaseBlindAlgoNameKeyboard: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdKeyboard,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewKeyboardAlgoOrPanic(NewKeyboardCostModel(KeyboardQWERTY))),
+	CaseBlindAlgoNameSmithWaterman: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdSmithWaterman,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewSmithWatermanAlgoOrPanic(DfltAlignScores)),
+	CaseBlindAlgoNameNeedlemanWunsch: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdNeedlemanWunsch,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewNeedlemanWunschAlgoOrPanic(DfltAlignScores)),
+	CaseBlindAlgoNameSoundex: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdSoundex,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewPhoneticAlgo(SoundexEncoder{}, nil)),
+	CaseBlindAlgoNameRefinedSoundex: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdRefinedSoundex,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewPhoneticAlgo(RefinedSoundexEncoder{}, nil)),
+	CaseBlindAlgoNameNYSIIS: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdNYSIIS,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewPhoneticAlgo(NYSIISEncoder{}, nil)),
+	CaseBlindAlgoNameDoubleMetaphone: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdDoubleMetaphone,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		NewMetaphoneAlgo(nil)),
+	CaseBlindAlgoNameEnsemble: NewFinderOrPanic(
+		FinderConfig{
+			Threshold:      DfltThresholdEnsemble,
+			MapToLowerCase: true,
+			MinStrLength:   DfltMinStrLength,
+		},
+		dfltEnsembleAlgo()),
+}
+
+// dfltEnsembleAlgo returns the EnsembleAlgo used by the default ensemble
+// Finders. This gives the mean of the scaled Levenshtein and cosine
+// distances.
+func dfltEnsembleAlgo() *EnsembleAlgo {
+	return NewEnsembleAlgoOrPanic(EnsembleWeightedMean,
+		EnsembleComponent{Algo: ScaledLevAlgo{}, Weight: 1},
+		EnsembleComponent{
+			Algo:   NewCosineAlgoOrPanic(DfltNGramConfig, DfltMaxCacheSize),
+			Weight: 1,
+		})
 }
@@ -0,0 +1,117 @@
+package strdist
+
+import (
+	"fmt"
+	"math"
+)
+
+// AlignScores holds the scores used by the alignment algorithms
+// (Smith-Waterman, Needleman-Wunsch). Matching runes increase the score of
+// an alignment and mismatched runes and gaps decrease it.
+//
+// A gap of length k (a run of k runes in one string with no counterpart in
+// the other) reduces the score by GapOpen + (k-1)*GapExtend. Setting
+// GapOpen greater than GapExtend gives affine gap penalties where a single
+// long gap is preferred to several short ones; setting them equal gives
+// linear gap penalties.
+type AlignScores struct {
+	// Match is the score for aligning a rune with itself, it must be > 0
+	Match float64
+	// Mismatch is the score for aligning two different runes, it must be
+	// less than the Match score and will typically be negative
+	Mismatch float64
+	// GapOpen is the penalty for the first rune of a gap, it must be >= 0
+	GapOpen float64
+	// GapExtend is the penalty for each subsequent rune of a gap, it must be
+	// >= 0
+	GapExtend float64
+	// SubMatrix gives the scores for aligning particular pairs of runes,
+	// overriding the Match and Mismatch scores. The score for the key {r1,
+	// r2} is also used for aligning r2 with r1 unless that has its own
+	// entry. Any entry for aligning a rune with itself must be > 0. It may
+	// be nil.
+	SubMatrix map[[2]rune]float64
+}
+
+// DfltAlignScores gives the default scores for the alignment algorithms.
+// These use affine gap penalties.
+var DfltAlignScores = AlignScores{
+	Match:     2,
+	Mismatch:  -1,
+	GapOpen:   2,
+	GapExtend: 1,
+}
+
+// Check returns a non-nil error if the scores are invalid
+func (sc AlignScores) Check() error {
+	if math.IsNaN(sc.Match) || math.IsInf(sc.Match, 0) || sc.Match <= 0 {
+		return fmt.Errorf("the Match score (%g) must be > 0", sc.Match)
+	}
+
+	if math.IsNaN(sc.Mismatch) || sc.Mismatch >= sc.Match {
+		return fmt.Errorf(
+			"the Mismatch score (%g) must be < the Match score (%g)",
+			sc.Mismatch, sc.Match)
+	}
+
+	for k, v := range sc.SubMatrix {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("the SubMatrix score for (%q, %q) (%g)"+
+				" must be a finite number",
+				k[0], k[1], v)
+		}
+
+		if k[0] == k[1] && v <= 0 {
+			return fmt.Errorf("the SubMatrix score for (%q, %q) (%g)"+
+				" must be > 0",
+				k[0], k[1], v)
+		}
+	}
+
+	if err := checkCost("GapOpen", sc.GapOpen); err != nil {
+		return err
+	}
+
+	return checkCost("GapExtend", sc.GapExtend)
+}
+
+// Desc returns a string describing the scores
+func (sc AlignScores) Desc() string {
+	s := fmt.Sprintf("Match: %.3g", sc.Match)
+	s += fmt.Sprintf(" Mismatch: %.3g", sc.Mismatch)
+	s += fmt.Sprintf(" GapOpen: %.3g", sc.GapOpen)
+	s += fmt.Sprintf(" GapExtend: %.3g", sc.GapExtend)
+
+	if sc.SubMatrix != nil {
+		s += fmt.Sprintf(" SubMatrix: %d entries", len(sc.SubMatrix))
+	}
+
+	return s
+}
+
+// score returns the score for aligning rune r1 with rune r2
+func (sc AlignScores) score(r1, r2 rune) float64 {
+	if s, ok := sc.SubMatrix[[2]rune{r1, r2}]; ok {
+		return s
+	}
+
+	if s, ok := sc.SubMatrix[[2]rune{r2, r1}]; ok {
+		return s
+	}
+
+	if r1 == r2 {
+		return sc.Match
+	}
+
+	return sc.Mismatch
+}
+
+// selfScore returns the score for aligning the runes with themselves
+func (sc AlignScores) selfScore(r []rune) float64 {
+	s := 0.0
+	for _, v := range r {
+		s += sc.score(v, v)
+	}
+
+	return s
+}
@@ -0,0 +1,376 @@
+package strdist
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// EditOp describes the edit operation performed at a step of an Alignment
+type EditOp int
+
+// These are the possible edit operations
+const (
+	EditMatch EditOp = iota
+	EditInsert
+	EditDelete
+	EditSubstitute
+	EditTranspose
+)
+
+// String returns a string describing the edit operation
+func (op EditOp) String() string {
+	switch op {
+	case EditMatch:
+		return "match"
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	case EditSubstitute:
+		return "substitute"
+	case EditTranspose:
+		return "transpose"
+	}
+
+	return fmt.Sprintf("EditOp(%d)", int(op))
+}
+
+// Edit describes a single step in an Alignment
+type Edit struct {
+	Op EditOp
+	// APos and BPos give the rune offsets (not byte offsets) in the two
+	// strings at which the edit applies. For an insertion APos is the
+	// offset in the first string before which the rune is inserted and for
+	// a deletion BPos is the offset in the second string at which the rune
+	// would have been.
+	APos, BPos int
+	// A and B give the runes from the two strings involved in the edit. A
+	// is empty for an insertion and B is empty for a deletion. For a
+	// transposition they each hold the two transposed runes.
+	A, B string
+}
+
+// Alignment records a sequence of edits transforming one string into
+// another together with the total cost (the distance) of those edits
+type Alignment struct {
+	Edits []Edit
+	Dist  float64
+}
+
+// LevenshteinAlignment returns an Alignment of strings a and b giving the
+// edits needed to transform a into b with the least Levenshtein distance.
+func LevenshteinAlignment(a, b string) Alignment {
+	return WeightedLevenshteinAlignment(a, b, LevenshteinCosts)
+}
+
+// DamerauLevenshteinAlignment returns an Alignment of strings a and b giving
+// the edits needed to transform a into b with the least (optimal string
+// alignment) Damerau-Levenshtein distance.
+func DamerauLevenshteinAlignment(a, b string) Alignment {
+	return WeightedLevenshteinAlignment(a, b, DamerauLevenshteinCosts)
+}
+
+// WeightedLevenshteinAlignment returns an Alignment of strings a and b
+// giving the edits needed to transform a into b with the least weighted
+// Levenshtein distance, using the costs given by the CostModel. If there is
+// no permitted way to transform a into b then the Dist will be +Inf and the
+// Edits will be nil.
+//
+// For long strings the alignment is found using Hirschberg's algorithm
+// (extended to allow for transpositions) and so the memory used is
+// proportional to the lengths of the strings rather than to the product of
+// their lengths.
+func WeightedLevenshteinAlignment(a, b string, cm CostModel) Alignment {
+	ra, rb := []rune(a), []rune(b)
+	_, last := weightedLevRows(ra, rb, cm, false)
+
+	al := Alignment{Dist: last[len(rb)]}
+	if math.IsInf(al.Dist, 1) {
+		return al
+	}
+
+	al.Edits = hirschberg(make([]Edit, 0, max(len(ra), len(rb))),
+		ra, rb, cm, 0, 0)
+
+	return al
+}
+
+// hirschbergMaxCells is the number of entries in the table of distances
+// below which the alignment is found directly from the full table rather
+// than by dividing the problem further
+const hirschbergMaxCells = 1 << 12
+
+// hirschberg appends to edits the edits aligning a and b, found using
+// Hirschberg's algorithm. The aOff and bOff values give the offsets of a
+// and b in the original strings.
+//
+// The algorithm finds the point at which an optimal alignment crosses the
+// middle row of the table of distances by combining the distances from the
+// start of the strings to the middle row with the distances from the end of
+// the strings to the middle row. This only needs a few rows of the table at
+// a time. The two halves of the problem are then aligned recursively. A
+// transposition is the only edit that can jump over the middle row and so
+// these are checked separately.
+func hirschberg(edits []Edit, a, b []rune, cm CostModel, aOff, bOff int,
+) []Edit {
+	const minLen = 4
+	if len(a) < minLen || len(b) < minLen ||
+		(len(a)+1)*(len(b)+1) <= hirschbergMaxCells {
+		return fullAlignment(edits, a, b, cm, aOff, bOff)
+	}
+
+	mid := len(a) / 2
+	m := len(b)
+
+	// fLast[j] is the distance between a[:mid] and b[:j] and fPrev[j] the
+	// distance between a[:mid-1] and b[:j]
+	fPrev, fLast := weightedLevRows(a[:mid], b, cm, false)
+
+	// bLast[k] is the distance between a[mid:] and b[m-k:] and bPrev[k]
+	// the distance between a[mid+1:] and b[m-k:]
+	bPrev, bLast := weightedLevRows(reversedRunes(a[mid:]), reversedRunes(b),
+		cm, true)
+
+	bestJ, bestCost, crossing := 0, math.Inf(1), false
+
+	for j := 0; j <= m; j++ {
+		if c := fLast[j] + bLast[m-j]; c < bestCost {
+			bestJ, bestCost = j, c
+		}
+	}
+
+	for j := 1; j < m; j++ {
+		if !isTransposition(a, b, mid, j) {
+			continue
+		}
+
+		c := fPrev[j-1] + cm.TransCost(a[mid-1], a[mid]) + bPrev[m-j-1]
+		if c < bestCost {
+			bestJ, bestCost, crossing = j, c, true
+		}
+	}
+
+	if !crossing {
+		edits = hirschberg(edits, a[:mid], b[:bestJ], cm, aOff, bOff)
+
+		return hirschberg(edits, a[mid:], b[bestJ:], cm,
+			aOff+mid, bOff+bestJ)
+	}
+
+	edits = hirschberg(edits, a[:mid-1], b[:bestJ-1], cm, aOff, bOff)
+	edits = append(edits, Edit{
+		Op:   EditTranspose,
+		APos: aOff + mid - 1,
+		BPos: bOff + bestJ - 1,
+		A:    string(a[mid-1 : mid+1]),
+		B:    string(b[bestJ-1 : bestJ+1]),
+	})
+
+	return hirschberg(edits, a[mid+1:], b[bestJ+1:], cm,
+		aOff+mid+1, bOff+bestJ+1)
+}
+
+// reversedRunes returns a reversed copy of the runes
+func reversedRunes(r []rune) []rune {
+	rev := slices.Clone(r)
+	slices.Reverse(rev)
+
+	return rev
+}
+
+// fullAlignment appends to edits the edits aligning a and b, found from the
+// full table of distances. The aOff and bOff values give the offsets of a
+// and b in the original strings. Where several sequences of edits have the
+// same cost, matches and substitutions are preferred to transpositions which
+// are preferred to deletions and then insertions.
+func fullAlignment(edits []Edit, a, b []rune, cm CostModel, aOff, bOff int,
+) []Edit {
+	cols := len(b) + 1
+
+	// d[i*cols+j] holds the distance between a[:i] and b[:j] and
+	// ops[i*cols+j] the last edit in the best sequence of edits
+	d := make([]float64, (len(a)+1)*cols)
+	ops := make([]EditOp, (len(a)+1)*cols)
+
+	for j, bRune := range b {
+		d[j+1] = d[j] + cm.InsCost(bRune)
+		ops[j+1] = EditInsert
+	}
+
+	for i, aRune := range a {
+		row, prevRow := (i+1)*cols, i*cols
+
+		d[row] = d[prevRow] + cm.DelCost(aRune)
+		ops[row] = EditDelete
+
+		for j, bRune := range b {
+			best, op := d[prevRow+j], EditMatch
+			if aRune != bRune {
+				best += cm.SubCost(aRune, bRune)
+				op = EditSubstitute
+			}
+
+			if isTransposition(a, b, i, j) {
+				if c := d[prevRow-cols+j-1] +
+					cm.TransCost(a[i-1], aRune); c < best {
+					best, op = c, EditTranspose
+				}
+			}
+
+			if c := d[prevRow+j+1] + cm.DelCost(aRune); c < best {
+				best, op = c, EditDelete
+			}
+
+			if c := d[row+j] + cm.InsCost(bRune); c < best {
+				best, op = c, EditInsert
+			}
+
+			d[row+j+1], ops[row+j+1] = best, op
+		}
+	}
+
+	start := len(edits)
+
+	for i, j := len(a), len(b); i > 0 || j > 0; {
+		switch ops[i*cols+j] {
+		case EditMatch, EditSubstitute:
+			edits = append(edits, mkSubEdit(a, b, i-1, j-1, aOff, bOff))
+			i--
+			j--
+		case EditTranspose:
+			edits = append(edits, Edit{
+				Op:   EditTranspose,
+				APos: aOff + i - 2,
+				BPos: bOff + j - 2,
+				A:    string(a[i-2 : i]),
+				B:    string(b[j-2 : j]),
+			})
+			i -= 2
+			j -= 2
+		case EditDelete:
+			edits = append(edits, mkDelEdit(a, i-1, aOff, bOff+j))
+			i--
+		case EditInsert:
+			edits = append(edits, mkInsEdit(b, j-1, aOff+i, bOff))
+			j--
+		}
+	}
+
+	slices.Reverse(edits[start:])
+
+	return edits
+}
+
+// mkSubEdit returns the Edit matching or substituting a[i] with b[j]. The
+// aOff and bOff values give the offsets of a and b in the original strings.
+func mkSubEdit(a, b []rune, i, j, aOff, bOff int) Edit {
+	op := EditMatch
+	if a[i] != b[j] {
+		op = EditSubstitute
+	}
+
+	return Edit{
+		Op:   op,
+		APos: aOff + i,
+		BPos: bOff + j,
+		A:    string(a[i]),
+		B:    string(b[j]),
+	}
+}
+
+// mkDelEdit returns the Edit deleting a[i] at offset bPos in the second
+// string. The aOff value gives the offset of a in the original string.
+func mkDelEdit(a []rune, i, aOff, bPos int) Edit {
+	return Edit{
+		Op:   EditDelete,
+		APos: aOff + i,
+		BPos: bPos,
+		A:    string(a[i]),
+	}
+}
+
+// mkInsEdit returns the Edit inserting b[j] at offset aPos in the first
+// string. The bOff value gives the offset of b in the original string.
+func mkInsEdit(b []rune, j, aPos, bOff int) Edit {
+	return Edit{
+		Op:   EditInsert,
+		APos: aPos,
+		BPos: bOff + j,
+		B:    string(b[j]),
+	}
+}
+
+// AlignmentMarkup holds the strings used to mark up the differences between
+// two strings when rendering an Alignment
+type AlignmentMarkup struct {
+	DelStart, DelEnd string
+	InsStart, InsEnd string
+}
+
+// These are some standard AlignmentMarkups. BracketMarkup shows deleted
+// runes as [-...] and inserted runes as [+...]. ANSIMarkup uses ANSI
+// terminal escape sequences to show deleted runes in red, struck through,
+// and inserted runes in green.
+var (
+	BracketMarkup = AlignmentMarkup{
+		DelStart: "[-",
+		DelEnd:   "]",
+		InsStart: "[+",
+		InsEnd:   "]",
+	}
+	ANSIMarkup = AlignmentMarkup{
+		DelStart: "\x1b[9;31m",
+		DelEnd:   "\x1b[0m",
+		InsStart: "\x1b[32m",
+		InsEnd:   "\x1b[0m",
+	}
+)
+
+// Markup returns a string showing the edits in the Alignment. Matching runes
+// are shown unchanged and each run of adjacent edits is shown as the
+// deleted runes followed by the inserted runes, marked up with the strings
+// from the AlignmentMarkup. Substitutions and transpositions are shown as a
+// deletion followed by an insertion.
+func (al Alignment) Markup(m AlignmentMarkup) string {
+	var s, del, ins strings.Builder
+
+	flush := func() {
+		if del.Len() > 0 {
+			s.WriteString(m.DelStart + del.String() + m.DelEnd)
+			del.Reset()
+		}
+
+		if ins.Len() > 0 {
+			s.WriteString(m.InsStart + ins.String() + m.InsEnd)
+			ins.Reset()
+		}
+	}
+
+	for _, e := range al.Edits {
+		if e.Op == EditMatch {
+			flush()
+			s.WriteString(e.A)
+
+			continue
+		}
+
+		del.WriteString(e.A)
+		ins.WriteString(e.B)
+	}
+
+	flush()
+
+	return s.String()
+}
+
+// ScoredAlignment records an Alignment found by one of the score-based
+// alignment algorithms (Smith-Waterman, Needleman-Wunsch) together with the
+// score of the alignment. The Dist of the Alignment is the normalised
+// distance given by the algorithm.
+type ScoredAlignment struct {
+	Alignment
+	// Score is the score of the alignment
+	Score float64
+}
@@ -0,0 +1,240 @@
+package strdist_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestEditOpString(t *testing.T) {
+	testCases := []struct {
+		op     strdist.EditOp
+		expStr string
+	}{
+		{op: strdist.EditMatch, expStr: "match"},
+		{op: strdist.EditInsert, expStr: "insert"},
+		{op: strdist.EditDelete, expStr: "delete"},
+		{op: strdist.EditSubstitute, expStr: "substitute"},
+		{op: strdist.EditTranspose, expStr: "transpose"},
+		{op: strdist.EditOp(99), expStr: "EditOp(99)"},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffString(t, tc.expStr, "EditOp.String()",
+			tc.op.String(), tc.expStr)
+	}
+}
+
+func TestAlignment(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b      string
+		damerau   bool
+		expDist   float64
+		expEdits  []strdist.Edit
+		expMarkup string
+	}{
+		{
+			ID:        testhelper.MkID("both empty"),
+			expDist:   0,
+			expMarkup: "",
+		},
+		{
+			ID:      testhelper.MkID("insert all"),
+			b:       "ab",
+			expDist: 2,
+			expEdits: []strdist.Edit{
+				{Op: strdist.EditInsert, APos: 0, BPos: 0, B: "a"},
+				{Op: strdist.EditInsert, APos: 0, BPos: 1, B: "b"},
+			},
+			expMarkup: "[+ab]",
+		},
+		{
+			ID:      testhelper.MkID("Kitten/Sitting"),
+			a:       "kitten",
+			b:       "sitting",
+			expDist: 3,
+			expEdits: []strdist.Edit{
+				{Op: strdist.EditSubstitute, APos: 0, BPos: 0, A: "k", B: "s"},
+				{Op: strdist.EditMatch, APos: 1, BPos: 1, A: "i", B: "i"},
+				{Op: strdist.EditMatch, APos: 2, BPos: 2, A: "t", B: "t"},
+				{Op: strdist.EditMatch, APos: 3, BPos: 3, A: "t", B: "t"},
+				{Op: strdist.EditSubstitute, APos: 4, BPos: 4, A: "e", B: "i"},
+				{Op: strdist.EditMatch, APos: 5, BPos: 5, A: "n", B: "n"},
+				{Op: strdist.EditInsert, APos: 6, BPos: 6, B: "g"},
+			},
+			expMarkup: "[-k][+s]itt[-e][+i]n[+g]",
+		},
+		{
+			ID:      testhelper.MkID("delete, multi-byte"),
+			a:       "a§¶b",
+			b:       "a¶b",
+			expDist: 1,
+			expEdits: []strdist.Edit{
+				{Op: strdist.EditMatch, APos: 0, BPos: 0, A: "a", B: "a"},
+				{Op: strdist.EditDelete, APos: 1, BPos: 1, A: "§"},
+				{Op: strdist.EditMatch, APos: 2, BPos: 1, A: "¶", B: "¶"},
+				{Op: strdist.EditMatch, APos: 3, BPos: 2, A: "b", B: "b"},
+			},
+			expMarkup: "a[-§]¶b",
+		},
+		{
+			ID:      testhelper.MkID("Levenshtein: transposed"),
+			a:       "gti",
+			b:       "git",
+			expDist: 2,
+			expEdits: []strdist.Edit{
+				{Op: strdist.EditMatch, APos: 0, BPos: 0, A: "g", B: "g"},
+				{Op: strdist.EditSubstitute, APos: 1, BPos: 1, A: "t", B: "i"},
+				{Op: strdist.EditSubstitute, APos: 2, BPos: 2, A: "i", B: "t"},
+			},
+			expMarkup: "g[-ti][+it]",
+		},
+		{
+			ID:      testhelper.MkID("Damerau: transposed"),
+			a:       "gti",
+			b:       "git",
+			damerau: true,
+			expDist: 1,
+			expEdits: []strdist.Edit{
+				{Op: strdist.EditMatch, APos: 0, BPos: 0, A: "g", B: "g"},
+				{Op: strdist.EditTranspose, APos: 1, BPos: 1, A: "ti", B: "it"},
+			},
+			expMarkup: "g[-ti][+it]",
+		},
+	}
+
+	for _, tc := range testCases {
+		var al strdist.Alignment
+		if tc.damerau {
+			al = strdist.DamerauLevenshteinAlignment(tc.a, tc.b)
+		} else {
+			al = strdist.LevenshteinAlignment(tc.a, tc.b)
+		}
+
+		testhelper.DiffFloat(t, tc.IDStr(), "distance", al.Dist, tc.expDist, 0)
+		testhelper.DiffSlice(t, tc.IDStr(), "edits", al.Edits, tc.expEdits)
+		testhelper.DiffString(t, tc.IDStr(), "markup",
+			al.Markup(strdist.BracketMarkup), tc.expMarkup)
+	}
+}
+
+func TestWeightedAlignment(t *testing.T) {
+	al := strdist.WeightedLevenshteinAlignment("B00K", "BOOK", strdist.OCRCosts)
+	testhelper.DiffFloat(t, "OCR", "distance", al.Dist, 0.5, 0)
+	testhelper.DiffString(t, "OCR", "markup",
+		al.Markup(strdist.ANSIMarkup),
+		"B\x1b[9;31m00\x1b[0m\x1b[32mOO\x1b[0mK")
+
+	noIns := strdist.OpCosts{Ins: math.Inf(1), Del: 1, Sub: 1}
+	al = strdist.WeightedLevenshteinAlignment("a", "ab", noIns)
+
+	if !math.IsInf(al.Dist, 1) {
+		t.Log("no insertions")
+		t.Errorf("\t: expected an infinite distance, got: %g\n", al.Dist)
+	}
+
+	if al.Edits != nil {
+		t.Log("no insertions")
+		t.Errorf("\t: expected no edits, got: %v\n", al.Edits)
+	}
+}
+
+// editsCost returns the total cost of the edits, using the CostModel. It
+// also checks that the edits transform a into b.
+func editsCost(t *testing.T, id string, edits []strdist.Edit,
+	a, b string, cm strdist.CostModel,
+) float64 {
+	t.Helper()
+
+	var cost float64
+
+	var aStr, bStr strings.Builder
+
+	aPos, bPos := 0, 0
+
+	for i, e := range edits {
+		if e.APos != aPos || e.BPos != bPos {
+			t.Log(id)
+			t.Errorf("\t: edit %d: bad position: %d, %d (expected %d, %d)\n",
+				i, e.APos, e.BPos, aPos, bPos)
+		}
+
+		ra, rb := []rune(e.A), []rune(e.B)
+
+		switch e.Op {
+		case strdist.EditSubstitute:
+			cost += cm.SubCost(ra[0], rb[0])
+		case strdist.EditInsert:
+			cost += cm.InsCost(rb[0])
+		case strdist.EditDelete:
+			cost += cm.DelCost(ra[0])
+		case strdist.EditTranspose:
+			cost += cm.TransCost(ra[0], ra[1])
+		}
+
+		aStr.WriteString(e.A)
+		bStr.WriteString(e.B)
+
+		aPos += len(ra)
+		bPos += len(rb)
+	}
+
+	testhelper.DiffString(t, id, "edited from", aStr.String(), a)
+	testhelper.DiffString(t, id, "edited to", bStr.String(), b)
+
+	return cost
+}
+
+func TestLongAlignment(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b string
+		cm   strdist.CostModel
+	}{
+		{
+			ID: testhelper.MkID("Levenshtein"),
+			a:  strings.Repeat("the quick brown fox jumps over the dog. ", 20),
+			b:  strings.Repeat("a quick brown fax jumped over the dog! ", 25),
+			cm: strdist.LevenshteinCosts,
+		},
+		{
+			ID: testhelper.MkID("Damerau-Levenshtein, transpositions"),
+			a:  strings.Repeat("abcdefgh", 100),
+			b:  strings.Repeat("bacdfegh", 100),
+			cm: strdist.DamerauLevenshteinCosts,
+		},
+		{
+			ID: testhelper.MkID("Damerau-Levenshtein, transposition at split"),
+			a:  strings.Repeat("x", 99) + "ab" + strings.Repeat("z", 99),
+			b:  strings.Repeat("x", 99) + "ba" + strings.Repeat("z", 99),
+			cm: strdist.DamerauLevenshteinCosts,
+		},
+		{
+			ID: testhelper.MkID("Damerau-Levenshtein, different lengths"),
+			a:  strings.Repeat("§¶abcd", 150),
+			b:  strings.Repeat("¶§bacde", 80),
+			cm: strdist.DamerauLevenshteinCosts,
+		},
+		{
+			ID: testhelper.MkID("OCR costs"),
+			a:  strings.Repeat("B00K 1ist ", 120),
+			b:  strings.Repeat("BOOK list ", 110),
+			cm: strdist.OCRCosts,
+		},
+	}
+
+	for _, tc := range testCases {
+		al := strdist.WeightedLevenshteinAlignment(tc.a, tc.b, tc.cm)
+		testhelper.DiffFloat(t, tc.IDStr(), "distance",
+			al.Dist, strdist.WeightedLevenshteinDistance(tc.a, tc.b, tc.cm),
+			1e-9)
+
+		cost := editsCost(t, tc.IDStr(), al.Edits, tc.a, tc.b, tc.cm)
+		testhelper.DiffFloat(t, tc.IDStr(), "cost of edits",
+			cost, al.Dist, 1e-9)
+	}
+}
@@ -1,6 +1,9 @@
 package strdist
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+)
 
 // DfltMaxCacheSize is the default for the max cache size
 const DfltMaxCacheSize = 3
@@ -11,8 +14,11 @@ type cacheEntry[T any] struct {
 	useCount int
 }
 
-// cache represents a cache of values of type T referenced by a name
+// cache represents a cache of values of type T referenced by a name. It is
+// safe for concurrent use so that an Algo using it can be shared between
+// goroutines (see NewDistanceMatrix).
 type cache[T any] struct {
+	mu           sync.Mutex
 	cache        map[string]cacheEntry[T]
 	maxCacheSize int
 }
@@ -33,12 +39,12 @@ func newCache[T any](maxCacheSize int) (*cache[T], error) {
 }
 
 // Desc returns a string describing the cache configuration
-func (c cache[T]) Desc() string {
+func (c *cache[T]) Desc() string {
 	return fmt.Sprintf("cache sz: %3d", c.maxCacheSize)
 }
 
 // clearLeastUsedCacheEntry removes the cache entry which has been used least
-// frequently
+// frequently. The cache must be locked.
 func (c *cache[T]) clearLeastUsedCacheEntry() {
 	if c.maxCacheSize == 0 { // there is no cache
 		return
@@ -73,6 +79,9 @@ func (c *cache[T]) clearLeastUsedCacheEntry() {
 // setCachedEntry sets the value of the cached entry in the cache. It first
 // removes the least heavily used entry (if necessary).
 func (c *cache[T]) setCachedEntry(key string, val T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.clearLeastUsedCacheEntry()
 
 	c.cache[key] = cacheEntry[T]{
@@ -85,6 +94,9 @@ func (c *cache[T]) setCachedEntry(key string, val T) {
 // returning the entry value and a bool indicating whether the entry was
 // found in the map
 func (c *cache[T]) getCachedEntry(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	ce, ok := c.cache[key]
 	if ok {
 		ce.useCount++
@@ -54,6 +54,24 @@ func (a *CosineAlgo) Dist(s1, s2 string) float64 {
 	return a.cosineDistance(sd1, sd2)
 }
 
+// cosineZeroDist is the distance below which a cosine distance is taken to
+// be zero. The distance between identical strings can differ slightly from
+// zero due to rounding errors.
+const cosineZeroDist = 1e-12
+
+// NormalizedDist for a CosineAlgo is the Dist limited to lie between 0 and
+// 1. Distances very close to zero, as can be given for identical strings
+// due to rounding errors, are taken to be zero so that identical strings
+// will be found even with a zero threshold.
+func (a *CosineAlgo) NormalizedDist(s1, s2 string) float64 {
+	d := clamp01(a.Dist(s1, s2))
+	if d < cosineZeroDist {
+		return 0
+	}
+
+	return d
+}
+
 // Name returns the algorithm Name
 func (a CosineAlgo) Name() string {
 	return AlgoNameCosine
@@ -0,0 +1,196 @@
+package strdist
+
+import (
+	"fmt"
+	"math"
+)
+
+// CostModel describes the interface that a cost model used by the weighted
+// edit distance algorithms must satisfy. Each method gives the cost of the
+// corresponding edit operation. A cost of +Inf indicates that the operation
+// is not permitted. Note that the cost of substituting a rune with itself is
+// never requested as it is always taken to be zero.
+type CostModel interface {
+	// InsCost returns the cost of inserting the rune
+	InsCost(r rune) float64
+	// DelCost returns the cost of deleting the rune
+	DelCost(r rune) float64
+	// SubCost returns the cost of substituting rune from with rune to
+	SubCost(from, to rune) float64
+	// TransCost returns the cost of transposing the adjacent runes r1 and
+	// r2 (so that r1 r2 becomes r2 r1)
+	TransCost(r1, r2 rune) float64
+	// Check returns a non-nil error if the cost model is invalid
+	Check() error
+	// Desc returns a string describing the cost model
+	Desc() string
+}
+
+// checkCost returns a non-nil error if the cost is negative or is not a
+// number
+func checkCost(name string, cost float64) error {
+	if math.IsNaN(cost) || cost < 0 {
+		return fmt.Errorf("the %s cost (%f) must be >= 0", name, cost)
+	}
+
+	return nil
+}
+
+// OpCosts is a CostModel giving a fixed cost for each edit operation
+// regardless of the runes involved. A cost of +Inf indicates that the
+// operation is not permitted.
+type OpCosts struct {
+	Ins   float64
+	Del   float64
+	Sub   float64
+	Trans float64
+}
+
+// These are some standard OpCosts. LevenshteinCosts gives the Levenshtein
+// distance and DamerauLevenshteinCosts gives the (optimal string alignment)
+// Damerau-Levenshtein distance which also allows adjacent runes to be
+// transposed.
+var (
+	LevenshteinCosts = OpCosts{
+		Ins:   1,
+		Del:   1,
+		Sub:   1,
+		Trans: math.Inf(1),
+	}
+	DamerauLevenshteinCosts = OpCosts{
+		Ins:   1,
+		Del:   1,
+		Sub:   1,
+		Trans: 1,
+	}
+)
+
+// InsCost returns the insertion cost
+func (oc OpCosts) InsCost(_ rune) float64 { return oc.Ins }
+
+// DelCost returns the deletion cost
+func (oc OpCosts) DelCost(_ rune) float64 { return oc.Del }
+
+// SubCost returns the substitution cost
+func (oc OpCosts) SubCost(_, _ rune) float64 { return oc.Sub }
+
+// TransCost returns the transposition cost
+func (oc OpCosts) TransCost(_, _ rune) float64 { return oc.Trans }
+
+// Check returns a non-nil error if any of the costs are negative
+func (oc OpCosts) Check() error {
+	if err := checkCost("insertion", oc.Ins); err != nil {
+		return err
+	}
+
+	if err := checkCost("deletion", oc.Del); err != nil {
+		return err
+	}
+
+	if err := checkCost("substitution", oc.Sub); err != nil {
+		return err
+	}
+
+	return checkCost("transposition", oc.Trans)
+}
+
+// Desc returns a string describing the costs
+func (oc OpCosts) Desc() string {
+	s := fmt.Sprintf("Ins: %.3g", oc.Ins)
+	s += fmt.Sprintf(" Del: %.3g", oc.Del)
+	s += fmt.Sprintf(" Sub: %.3g", oc.Sub)
+	s += fmt.Sprintf(" Trans: %.3g", oc.Trans)
+
+	return s
+}
+
+// SubCostTable is a CostModel which allows the costs of substituting
+// particular pairs of runes to be given. Any pair not in the table has the
+// substitution cost given by the OpCosts. The OpCosts also give the costs of
+// the other operations.
+type SubCostTable struct {
+	OpCosts
+	subCosts map[[2]rune]float64
+}
+
+// NewSubCostTable returns a new SubCostTable. The subCosts map gives the
+// cost of substituting the first rune of the key with the second. The cost
+// is also used for the reverse substitution unless that has its own entry.
+func NewSubCostTable(oc OpCosts, subCosts map[[2]rune]float64) (
+	*SubCostTable, error,
+) {
+	sct := &SubCostTable{
+		OpCosts:  oc,
+		subCosts: make(map[[2]rune]float64, 2*len(subCosts)),
+	}
+
+	for k, v := range subCosts {
+		if err := checkCost(fmt.Sprintf("substitution (%q->%q)", k[0], k[1]),
+			v); err != nil {
+			return nil, err
+		}
+
+		sct.subCosts[k] = v
+
+		rev := [2]rune{k[1], k[0]}
+		if _, ok := subCosts[rev]; !ok {
+			sct.subCosts[rev] = v
+		}
+	}
+
+	if err := sct.Check(); err != nil {
+		return nil, err
+	}
+
+	return sct, nil
+}
+
+// NewSubCostTableOrPanic returns a new SubCostTable. It will panic if the
+// table cannot be created without errors.
+func NewSubCostTableOrPanic(oc OpCosts, subCosts map[[2]rune]float64,
+) *SubCostTable {
+	sct, err := NewSubCostTable(oc, subCosts)
+	if err != nil {
+		panic(err)
+	}
+
+	return sct
+}
+
+// SubCost returns the cost of substituting rune from with rune to. This is
+// taken from the table if present and from the OpCosts otherwise.
+func (sct SubCostTable) SubCost(from, to rune) float64 {
+	if c, ok := sct.subCosts[[2]rune{from, to}]; ok {
+		return c
+	}
+
+	return sct.Sub
+}
+
+// Desc returns a string describing the costs
+func (sct SubCostTable) Desc() string {
+	return sct.OpCosts.Desc() +
+		fmt.Sprintf(" SubTable: %d entries", len(sct.subCosts))
+}
+
+// OCRCosts is a CostModel with Levenshtein costs except that substitutions
+// between runes that are commonly confused by Optical Character Recognition
+// (OCR) are cheaper.
+var OCRCosts = NewSubCostTableOrPanic(LevenshteinCosts,
+	map[[2]rune]float64{
+		{'0', 'O'}: 0.25,
+		{'0', 'o'}: 0.25,
+		{'O', 'o'}: 0.25,
+		{'0', 'D'}: 0.5,
+		{'1', 'l'}: 0.25,
+		{'1', 'I'}: 0.25,
+		{'l', 'I'}: 0.25,
+		{'1', 'i'}: 0.5,
+		{'l', 'i'}: 0.5,
+		{'2', 'Z'}: 0.5,
+		{'5', 'S'}: 0.5,
+		{'6', 'G'}: 0.5,
+		{'8', 'B'}: 0.5,
+		{'c', 'e'}: 0.5,
+		{'u', 'v'}: 0.5,
+	})
@@ -0,0 +1,93 @@
+package strdist_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestOpCostsCheck(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		oc strdist.OpCosts
+	}{
+		{
+			ID: testhelper.MkID("good"),
+			oc: strdist.OpCosts{Ins: 1, Del: 2, Sub: 0, Trans: math.Inf(1)},
+		},
+		{
+			ID: testhelper.MkID("bad insertion cost"),
+			oc: strdist.OpCosts{Ins: -1},
+			ExpErr: testhelper.MkExpErr(
+				"the insertion cost (-1.000000) must be >= 0"),
+		},
+		{
+			ID: testhelper.MkID("bad deletion cost"),
+			oc: strdist.OpCosts{Del: -1},
+			ExpErr: testhelper.MkExpErr(
+				"the deletion cost (-1.000000) must be >= 0"),
+		},
+		{
+			ID: testhelper.MkID("bad substitution cost"),
+			oc: strdist.OpCosts{Sub: math.NaN()},
+			ExpErr: testhelper.MkExpErr(
+				"the substitution cost (NaN) must be >= 0"),
+		},
+		{
+			ID: testhelper.MkID("bad transposition cost"),
+			oc: strdist.OpCosts{Trans: math.Inf(-1)},
+			ExpErr: testhelper.MkExpErr(
+				"the transposition cost (-Inf) must be >= 0"),
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.oc.Check()
+		testhelper.CheckExpErr(t, err, tc)
+
+		_, err = strdist.NewWeightedLevenshteinAlgo(tc.oc)
+		testhelper.CheckExpErr(t, err, tc)
+	}
+}
+
+func TestSubCostTable(t *testing.T) {
+	_, err := strdist.NewSubCostTable(strdist.LevenshteinCosts,
+		map[[2]rune]float64{{'a', 'b'}: -0.5})
+	testhelper.CheckExpErrWithID(t, "bad table entry", err,
+		testhelper.MkExpErr(
+			`the substitution ('a'->'b') cost (-0.500000) must be >= 0`))
+
+	_, err = strdist.NewSubCostTable(strdist.OpCosts{Ins: -2}, nil)
+	testhelper.CheckExpErrWithID(t, "bad OpCosts", err,
+		testhelper.MkExpErr("the insertion cost (-2.000000) must be >= 0"))
+
+	sct, err := strdist.NewSubCostTable(strdist.LevenshteinCosts,
+		map[[2]rune]float64{
+			{'a', 'b'}: 0.5,
+			{'x', 'y'}: 0.2,
+			{'y', 'x'}: 0.7,
+		})
+	if err != nil {
+		t.Fatal("unexpected error creating the SubCostTable:", err)
+	}
+
+	testCases := []struct {
+		testhelper.ID
+		from, to rune
+		expCost  float64
+	}{
+		{ID: testhelper.MkID("in table"), from: 'a', to: 'b', expCost: 0.5},
+		{ID: testhelper.MkID("reversed"), from: 'b', to: 'a', expCost: 0.5},
+		{ID: testhelper.MkID("asymmetric"), from: 'x', to: 'y', expCost: 0.2},
+		{ID: testhelper.MkID("asymmetric, rev"), from: 'y', to: 'x', expCost: 0.7},
+		{ID: testhelper.MkID("not in table"), from: 'a', to: 'c', expCost: 1},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffFloat(t, tc.IDStr(), "substitution cost",
+			sct.SubCost(tc.from, tc.to), tc.expCost, 0)
+	}
+}
@@ -0,0 +1,241 @@
+package strdist
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// DistanceMatrix holds the distances between every pair of strings in a
+// population. This is useful for clustering or other analysis of the
+// population where the same distances would otherwise be calculated many
+// times.
+//
+// The distances are assumed to be symmetric (the distance from s1 to s2 is
+// the same as from s2 to s1) and the distance from a string to itself is
+// taken to be zero so only the upper triangle of the matrix is held.
+type DistanceMatrix struct {
+	fc    FinderConfig
+	pop   []string
+	idx   map[string]int
+	dists []float64
+}
+
+// NewDistanceMatrix calculates the distances between every pair of strings
+// in the population and returns them in a new DistanceMatrix. The
+// FinderConfig and Algo must be valid for a Finder (see NewFinder), the
+// Algo must not be nil and it must not give population-relative distances
+// (see EnsembleRankFusion). The strings are converted according to the
+// FinderConfig (mapped to lower case and so on) before the distances are
+// calculated and, if the FinderConfig is Normalized, the normalised
+// distances are used. The Threshold and the length limits are not applied.
+//
+// The distances are calculated in parallel so the Algo must be safe for
+// concurrent use; all the Algos in this package are.
+func NewDistanceMatrix(fc FinderConfig, algo Algo, pop ...string,
+) (*DistanceMatrix, error) {
+	if algo == nil {
+		return nil, errors.New("the Algo must not be nil")
+	}
+
+	if _, ok := popRanker(algo); ok {
+		return nil, fmt.Errorf(
+			"the %s Algo cannot be used by a DistanceMatrix"+
+				" as it ranks the whole population",
+			algo.Name())
+	}
+
+	f, err := NewFinder(fc, algo)
+	if err != nil {
+		return nil, err
+	}
+
+	dm := &DistanceMatrix{
+		fc:    fc,
+		pop:   slices.Clone(pop),
+		idx:   make(map[string]int, len(pop)),
+		dists: make([]float64, len(pop)*(len(pop)-1)/2),
+	}
+
+	for i, p := range dm.pop {
+		if _, ok := dm.idx[p]; !ok {
+			dm.idx[p] = i
+		}
+	}
+
+	dm.calcDists(f)
+
+	return dm, nil
+}
+
+// NewDistanceMatrixOrPanic returns a new DistanceMatrix. It will panic if
+// the DistanceMatrix cannot be created without errors.
+func NewDistanceMatrixOrPanic(fc FinderConfig, algo Algo, pop ...string,
+) *DistanceMatrix {
+	dm, err := NewDistanceMatrix(fc, algo, pop...)
+	if err != nil {
+		panic(err)
+	}
+
+	return dm
+}
+
+// calcDists calculates the distances between each pair of strings. Each
+// row of the upper triangle is calculated by a single goroutine; the rows
+// are shared out between the goroutines as they become free since the
+// rows get shorter as the matrix is traversed.
+func (dm *DistanceMatrix) calcDists(f *Finder) {
+	prepped := make([]string, 0, len(dm.pop))
+	for _, p := range dm.pop {
+		prepped = append(prepped, f.prepStr(p))
+	}
+
+	dist := f.Algo.Dist
+	if f.Normalized {
+		dist = normalizedDistFunc(f.Algo)
+	}
+
+	var (
+		nextRow atomic.Int64
+		wg      sync.WaitGroup
+	)
+
+	for range min(runtime.GOMAXPROCS(0), len(prepped)) {
+		wg.Go(func() {
+			for {
+				i := int(nextRow.Add(1) - 1)
+				if i >= len(prepped) {
+					return
+				}
+
+				for j := i + 1; j < len(prepped); j++ {
+					dm.dists[dm.offset(i, j)] = dist(prepped[i], prepped[j])
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+// offset returns the offset into the dists slice of the distance between
+// the i'th and j'th strings. The i'th string must be before the j'th.
+func (dm *DistanceMatrix) offset(i, j int) int {
+	n := len(dm.pop)
+
+	return i*(2*n-i-1)/2 + (j - i - 1)
+}
+
+// Len returns the number of strings in the population
+func (dm *DistanceMatrix) Len() int {
+	return len(dm.pop)
+}
+
+// Str returns the i'th string in the population
+func (dm *DistanceMatrix) Str(i int) string {
+	return dm.pop[i]
+}
+
+// Index returns the index of the first occurrence of the string in the
+// population. It returns false if the string is not in the population.
+func (dm *DistanceMatrix) Index(s string) (int, bool) {
+	i, ok := dm.idx[s]
+
+	return i, ok
+}
+
+// Dist returns the distance between the i'th and j'th strings in the
+// population. It will panic if either index is out of range.
+func (dm *DistanceMatrix) Dist(i, j int) float64 {
+	if i < 0 || i >= len(dm.pop) || j < 0 || j >= len(dm.pop) {
+		panic(fmt.Errorf("index out of range [%d, %d] with length %d",
+			i, j, len(dm.pop)))
+	}
+
+	switch {
+	case i < j:
+		return dm.dists[dm.offset(i, j)]
+	case i > j:
+		return dm.dists[dm.offset(j, i)]
+	}
+
+	return 0
+}
+
+// StrDist returns the distance between the two strings. It returns false
+// if either string is not in the population.
+func (dm *DistanceMatrix) StrDist(s1, s2 string) (float64, bool) {
+	i, ok := dm.idx[s1]
+	if !ok {
+		return 0, false
+	}
+
+	j, ok := dm.idx[s2]
+	if !ok {
+		return 0, false
+	}
+
+	return dm.Dist(i, j), true
+}
+
+// Nearest returns StrDists for the n strings in the population nearest to
+// the i'th string (not including the i'th string itself). They are in the
+// same order as given by a Finder with the same FinderConfig. If n is
+// negative then all the other strings are returned.
+func (dm *DistanceMatrix) Nearest(i, n int) []StrDist {
+	dists := make([]StrDist, 0, len(dm.pop))
+
+	for j, p := range dm.pop {
+		if j != i {
+			dists = append(dists, StrDist{Str: p, Dist: dm.Dist(i, j)})
+		}
+	}
+
+	sortByDist(dists, dm.fc.CmpFunc(dm.pop[i]),
+		func(sd StrDist) StrDist { return sd })
+
+	if n >= 0 && n < len(dists) {
+		dists = dists[:n]
+	}
+
+	return dists
+}
+
+// WriteCSV writes the full matrix to the writer in CSV format. The first
+// record has an empty field followed by the population strings; each
+// following record has a population string followed by its distances from
+// each of the population strings.
+func (dm *DistanceMatrix) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	rec := make([]string, 0, len(dm.pop)+1)
+	rec = append(rec, "")
+	rec = append(rec, dm.pop...)
+
+	if err := cw.Write(rec); err != nil {
+		return err
+	}
+
+	for i, p := range dm.pop {
+		rec = rec[:0]
+		rec = append(rec, p)
+
+		for j := range dm.pop {
+			rec = append(rec, strconv.FormatFloat(dm.Dist(i, j), 'g', -1, 64))
+		}
+
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,197 @@
+package strdist_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewDistanceMatrix(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		fc   strdist.FinderConfig
+		algo strdist.Algo
+	}{
+		{
+			ID:   testhelper.MkID("good"),
+			algo: strdist.LevenshteinAlgo{},
+		},
+		{
+			ID:     testhelper.MkID("nil Algo"),
+			ExpErr: testhelper.MkExpErr("the Algo must not be nil"),
+		},
+		{
+			ID:   testhelper.MkID("bad FinderConfig"),
+			fc:   strdist.FinderConfig{Threshold: -1},
+			algo: strdist.LevenshteinAlgo{},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the Threshold (-1.000000) must be >= 0"),
+		},
+		{
+			ID:   testhelper.MkID("not a NormalizedAlgo"),
+			fc:   strdist.FinderConfig{Normalized: true},
+			algo: TestAlgo{},
+			ExpErr: testhelper.MkExpErr(
+				"the TestAlgo Algo must be a NormalizedAlgo"),
+		},
+	}
+
+	for _, tc := range testCases {
+		dm, err := strdist.NewDistanceMatrix(tc.fc, tc.algo, "a", "b")
+		if testhelper.CheckExpErr(t, err, tc) && err == nil && dm == nil {
+			t.Log(tc.IDStr())
+			t.Errorf("\t: a nil pointer was returned but no error\n")
+		}
+	}
+}
+
+func TestDistanceMatrix(t *testing.T) {
+	pop := []string{"Colour", "color", "collar", "cooler", "flavour", "color"}
+
+	testCases := []struct {
+		testhelper.ID
+		fc   strdist.FinderConfig
+		algo strdist.Algo
+	}{
+		{
+			ID:   testhelper.MkID("Levenshtein"),
+			algo: strdist.LevenshteinAlgo{},
+		},
+		{
+			ID:   testhelper.MkID("Levenshtein, case-blind"),
+			fc:   strdist.FinderConfig{MapToLowerCase: true},
+			algo: strdist.LevenshteinAlgo{},
+		},
+		{
+			ID:   testhelper.MkID("Levenshtein, normalized"),
+			fc:   strdist.FinderConfig{Normalized: true},
+			algo: strdist.LevenshteinAlgo{},
+		},
+		{
+			ID:   testhelper.MkID("Jaccard (cached)"),
+			algo: strdist.NewJaccardAlgoOrPanic(strdist.DfltNGramConfig, 2),
+		},
+	}
+
+	for _, tc := range testCases {
+		dm := strdist.NewDistanceMatrixOrPanic(tc.fc, tc.algo, pop...)
+
+		// a Finder with a threshold large enough to find every string
+		refFC := tc.fc
+		refFC.Threshold = 100
+		if refFC.Normalized {
+			refFC.Threshold = 1
+		}
+
+		f := strdist.NewFinderOrPanic(refFC, tc.algo)
+
+		testhelper.DiffInt(t, tc.IDStr(), "Len", dm.Len(), len(pop))
+
+		for i, s1 := range pop {
+			testhelper.DiffString(t, tc.IDStr(), "Str", dm.Str(i), s1)
+
+			for j, s2 := range pop {
+				id := fmt.Sprintf("%s: Dist(%d, %d)", tc.IDStr(), i, j)
+
+				var exp float64
+				if i != j {
+					exp = f.FindLike(s1, s2)[0].Dist
+				}
+
+				testhelper.DiffFloat(t, id, "", dm.Dist(i, j), exp, 1e-12)
+			}
+		}
+	}
+}
+
+func TestDistanceMatrixLookup(t *testing.T) {
+	pop := []string{"colour", "color", "collar", "cooler", "color"}
+	dm := strdist.NewDistanceMatrixOrPanic(
+		strdist.FinderConfig{}, strdist.LevenshteinAlgo{}, pop...)
+
+	i, ok := dm.Index("color")
+	testhelper.DiffBool(t, "Index", "color - ok", ok, true)
+	testhelper.DiffInt(t, "Index", "color", i, 1)
+
+	_, ok = dm.Index("flavour")
+	testhelper.DiffBool(t, "Index", "flavour - ok", ok, false)
+
+	d, ok := dm.StrDist("colour", "cooler")
+	testhelper.DiffBool(t, "StrDist", "colour/cooler - ok", ok, true)
+	testhelper.DiffFloat(t, "StrDist", "colour/cooler", d, 3, 0)
+
+	_, ok = dm.StrDist("colour", "flavour")
+	testhelper.DiffBool(t, "StrDist", "colour/flavour - ok", ok, false)
+
+	testCases := []struct {
+		testhelper.ID
+		i   int
+		n   int
+		exp []strdist.StrDist
+	}{
+		{
+			ID: testhelper.MkID("all"),
+			i:  0,
+			n:  -1,
+			exp: []strdist.StrDist{
+				{Str: "color", Dist: 1},
+				{Str: "color", Dist: 1},
+				{Str: "collar", Dist: 2},
+				{Str: "cooler", Dist: 3},
+			},
+		},
+		{
+			ID: testhelper.MkID("nearest 2"),
+			i:  3,
+			n:  2,
+			exp: []strdist.StrDist{
+				{Str: "collar", Dist: 2},
+				{Str: "color", Dist: 2},
+			},
+		},
+		{
+			ID:  testhelper.MkID("none"),
+			i:   1,
+			n:   0,
+			exp: []strdist.StrDist{},
+		},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffSlice(t, tc.IDStr(), "Nearest",
+			dm.Nearest(tc.i, tc.n), tc.exp)
+	}
+}
+
+func TestDistanceMatrixWriteCSV(t *testing.T) {
+	dm := strdist.NewDistanceMatrixOrPanic(
+		strdist.FinderConfig{Normalized: true}, strdist.LevenshteinAlgo{},
+		"abcd", "abce", "a,b")
+
+	var buf bytes.Buffer
+	if err := dm.WriteCSV(&buf); err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	testhelper.DiffString(t, "WriteCSV", "", buf.String(),
+		`,abcd,abce,"a,b"`+"\n"+
+			"abcd,0,0.25,0.75\n"+
+			"abce,0.25,0,0.75\n"+
+			`"a,b",0.75,0.75,0`+"\n")
+}
+
+func TestDistanceMatrixEmpty(t *testing.T) {
+	dm := strdist.NewDistanceMatrixOrPanic(
+		strdist.FinderConfig{}, strdist.LevenshteinAlgo{})
+	testhelper.DiffInt(t, "empty", "Len", dm.Len(), 0)
+
+	dm = strdist.NewDistanceMatrixOrPanic(
+		strdist.FinderConfig{}, strdist.LevenshteinAlgo{}, "solo")
+	testhelper.DiffInt(t, "single", "Len", dm.Len(), 1)
+	testhelper.DiffFloat(t, "single", "Dist", dm.Dist(0, 0), 0, 0)
+	testhelper.DiffInt(t, "single", "Nearest", len(dm.Nearest(0, -1)), 0)
+}
@@ -0,0 +1,861 @@
+package strdist
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+// DoubleMetaphoneCodeLen is the maximum length of the codes given by
+// DoubleMetaphone
+const DoubleMetaphoneCodeLen = 4
+
+// DoubleMetaphoneEncoder is a MultiPhoneticEncoder giving the Double
+// Metaphone codes. Note that the MetaphoneAlgo compares the alternate codes
+// as well and so will typically give better matches than a PhoneticAlgo
+// using this encoder.
+type DoubleMetaphoneEncoder struct{}
+
+// Name returns the name of the encoding
+func (DoubleMetaphoneEncoder) Name() string { return AlgoNameDoubleMetaphone }
+
+// Encode returns the primary Double Metaphone code for the string
+func (DoubleMetaphoneEncoder) Encode(s string) string {
+	p, _ := DoubleMetaphone(s)
+	return p
+}
+
+// EncodeAll returns the primary Double Metaphone code for the string and
+// the alternate code if it is different
+func (DoubleMetaphoneEncoder) EncodeAll(s string) []string {
+	p, alt := DoubleMetaphone(s)
+	if alt == p {
+		return []string{p}
+	}
+
+	return []string{p, alt}
+}
+
+// DoubleMetaphone returns the primary and alternate Double Metaphone codes
+// for the string. Double Metaphone (due to Lawrence Philips) gives codes
+// reflecting the pronunciation of names from many languages (English,
+// Slavic, Germanic, Celtic, Greek, French, Italian, Spanish, Chinese and
+// others). The alternate code gives a second pronunciation where one is
+// likely; otherwise it is the same as the primary code. The codes are at
+// most DoubleMetaphoneCodeLen characters long. The string is not case
+// sensitive; leading and trailing white space is ignored. If the string is
+// empty both codes are empty.
+//
+// This follows the Apache Commons Codec implementation.
+func DoubleMetaphone(s string) (primary, alternate string) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return "", ""
+	}
+
+	dm := &dmEncoder{
+		val:       []rune(s),
+		maxLen:    DoubleMetaphoneCodeLen,
+		primary:   make([]byte, 0, DoubleMetaphoneCodeLen),
+		alternate: make([]byte, 0, DoubleMetaphoneCodeLen),
+	}
+	dm.encode()
+
+	return string(dm.primary), string(dm.alternate)
+}
+
+// dmEncoder holds the state of a Double Metaphone encoding
+type dmEncoder struct {
+	val           []rune
+	slavoGermanic bool
+	maxLen        int
+	primary       []byte
+	alternate     []byte
+}
+
+// encode generates the codes
+func (dm *dmEncoder) encode() {
+	dm.slavoGermanic = dm.isSlavoGermanic()
+
+	idx := 0
+	if dm.contains(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		idx = 1
+	}
+
+	last := len(dm.val) - 1
+
+	for !dm.isComplete() && idx <= last {
+		switch dm.charAt(idx) {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if idx == 0 {
+				dm.add("A")
+			}
+
+			idx++
+		case 'B':
+			dm.add("P")
+			idx = dm.skipIf(idx, 'B')
+		case 'Ç':
+			dm.add("S")
+			idx++
+		case 'C':
+			idx = dm.handleC(idx)
+		case 'D':
+			idx = dm.handleD(idx)
+		case 'F':
+			dm.add("F")
+			idx = dm.skipIf(idx, 'F')
+		case 'G':
+			idx = dm.handleG(idx)
+		case 'H':
+			idx = dm.handleH(idx)
+		case 'J':
+			idx = dm.handleJ(idx)
+		case 'K':
+			dm.add("K")
+			idx = dm.skipIf(idx, 'K')
+		case 'L':
+			idx = dm.handleL(idx)
+		case 'M':
+			dm.add("M")
+
+			idx++
+			if dm.conditionM0(idx - 1) {
+				idx++
+			}
+		case 'N':
+			dm.add("N")
+			idx = dm.skipIf(idx, 'N')
+		case 'Ñ':
+			dm.add("N")
+			idx++
+		case 'P':
+			idx = dm.handleP(idx)
+		case 'Q':
+			dm.add("K")
+			idx = dm.skipIf(idx, 'Q')
+		case 'R':
+			idx = dm.handleR(idx)
+		case 'S':
+			idx = dm.handleS(idx)
+		case 'T':
+			idx = dm.handleT(idx)
+		case 'V':
+			dm.add("F")
+			idx = dm.skipIf(idx, 'V')
+		case 'W':
+			idx = dm.handleW(idx)
+		case 'X':
+			idx = dm.handleX(idx)
+		case 'Z':
+			idx = dm.handleZ(idx)
+		default:
+			idx++
+		}
+	}
+}
+
+// isComplete returns true if both codes are of the maximum length
+func (dm *dmEncoder) isComplete() bool {
+	return len(dm.primary) >= dm.maxLen && len(dm.alternate) >= dm.maxLen
+}
+
+// addPrimary adds the code to the primary code, truncating it if necessary
+func (dm *dmEncoder) addPrimary(code string) {
+	dm.primary = dmAppend(dm.primary, code, dm.maxLen)
+}
+
+// addAlternate adds the code to the alternate code, truncating it if
+// necessary
+func (dm *dmEncoder) addAlternate(code string) {
+	dm.alternate = dmAppend(dm.alternate, code, dm.maxLen)
+}
+
+// add adds the code to both the primary and alternate codes
+func (dm *dmEncoder) add(code string) {
+	dm.addPrimary(code)
+	dm.addAlternate(code)
+}
+
+// add2 adds the first code to the primary code and the second to the
+// alternate code
+func (dm *dmEncoder) add2(primary, alternate string) {
+	dm.addPrimary(primary)
+	dm.addAlternate(alternate)
+}
+
+// dmAppend appends as much of the code to the slice as will fit within the
+// maximum length
+func dmAppend(b []byte, code string, maxLen int) []byte {
+	if room := maxLen - len(b); len(code) > room {
+		code = code[:max(room, 0)]
+	}
+
+	return append(b, code...)
+}
+
+// charAt returns the rune at the given index or 0 if the index is out of
+// range
+func (dm *dmEncoder) charAt(idx int) rune {
+	if idx < 0 || idx >= len(dm.val) {
+		return 0
+	}
+
+	return dm.val[idx]
+}
+
+// contains returns true if the length runes starting at the given index are
+// equal to any of the candidates
+func (dm *dmEncoder) contains(start, length int, candidates ...string) bool {
+	if start < 0 || start+length > len(dm.val) {
+		return false
+	}
+
+	target := string(dm.val[start : start+length])
+	for _, c := range candidates {
+		if target == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isVowel returns true if the rune at the given index is a vowel
+func (dm *dmEncoder) isVowel(idx int) bool {
+	return strings.ContainsRune("AEIOUY", dm.charAt(idx))
+}
+
+// skipIf returns the index of the next rune to be encoded, skipping the
+// following rune if it is the same as r
+func (dm *dmEncoder) skipIf(idx int, r rune) int {
+	if dm.charAt(idx+1) == r {
+		return idx + 2
+	}
+
+	return idx + 1
+}
+
+// isSlavoGermanic returns true if the string looks Slavic or Germanic
+func (dm *dmEncoder) isSlavoGermanic() bool {
+	s := string(dm.val)
+
+	return strings.ContainsAny(s, "WK") ||
+		strings.Contains(s, "CZ") ||
+		strings.Contains(s, "WITZ")
+}
+
+// startsGermanic returns true if the string starts in a Germanic way
+func (dm *dmEncoder) startsGermanic() bool {
+	return dm.contains(0, 4, "VAN ", "VON ") || dm.contains(0, 3, "SCH")
+}
+
+// handleC encodes a 'C'
+func (dm *dmEncoder) handleC(idx int) int {
+	switch {
+	case dm.conditionC0(idx):
+		dm.add("K")
+		return idx + 2
+	case idx == 0 && dm.contains(idx, 6, "CAESAR"):
+		dm.add("S")
+		return idx + 2
+	case dm.contains(idx, 2, "CH"):
+		return dm.handleCH(idx)
+	case dm.contains(idx, 2, "CZ") && !dm.contains(idx-2, 4, "WICZ"):
+		// "Czerny"
+		dm.add2("S", "X")
+		return idx + 2
+	case dm.contains(idx+1, 3, "CIA"):
+		// "focaccia"
+		dm.add("X")
+		return idx + 3
+	case dm.contains(idx, 2, "CC") && !(idx == 1 && dm.charAt(0) == 'M'):
+		// double "cc" but not "McClelland"
+		return dm.handleCC(idx)
+	case dm.contains(idx, 2, "CK", "CG", "CQ"):
+		dm.add("K")
+		return idx + 2
+	case dm.contains(idx, 2, "CI", "CE", "CY"):
+		// Italian vs. English
+		if dm.contains(idx, 3, "CIO", "CIE", "CIA") {
+			dm.add2("S", "X")
+		} else {
+			dm.add("S")
+		}
+
+		return idx + 2
+	}
+
+	dm.add("K")
+
+	switch {
+	case dm.contains(idx+1, 2, " C", " Q", " G"):
+		// "Mac Caffrey", "Mac Gregor"
+		return idx + 3
+	case dm.contains(idx+1, 1, "C", "K", "Q") &&
+		!dm.contains(idx+1, 2, "CE", "CI"):
+		return idx + 2
+	}
+
+	return idx + 1
+}
+
+// handleCC encodes a "CC"
+func (dm *dmEncoder) handleCC(idx int) int {
+	if dm.contains(idx+2, 1, "I", "E", "H") &&
+		!dm.contains(idx+2, 2, "HU") {
+		// "bellocchio" but not "bacchus"
+		if (idx == 1 && dm.charAt(idx-1) == 'A') ||
+			dm.contains(idx-1, 5, "UCCEE", "UCCES") {
+			// "accident", "accede", "succeed"
+			dm.add("KS")
+		} else {
+			// "bacci", "bertucci", other Italian
+			dm.add("X")
+		}
+
+		return idx + 3
+	}
+
+	// Pierce's rule
+	dm.add("K")
+
+	return idx + 2
+}
+
+// handleCH encodes a "CH"
+func (dm *dmEncoder) handleCH(idx int) int {
+	switch {
+	case idx > 0 && dm.contains(idx, 4, "CHAE"):
+		// "Michael"
+		dm.add2("K", "X")
+	case dm.conditionCH0(idx):
+		// Greek roots ("chemistry", "chorus", etc.)
+		dm.add("K")
+	case dm.conditionCH1(idx):
+		// Germanic, Greek, or otherwise 'ch' for 'kh' sound
+		dm.add("K")
+	case idx > 0:
+		if dm.contains(0, 2, "MC") {
+			dm.add("K")
+		} else {
+			dm.add2("X", "K")
+		}
+	default:
+		dm.add("X")
+	}
+
+	return idx + 2
+}
+
+// handleD encodes a 'D'
+func (dm *dmEncoder) handleD(idx int) int {
+	switch {
+	case dm.contains(idx, 2, "DG"):
+		if dm.contains(idx+2, 1, "I", "E", "Y") {
+			// "edge"
+			dm.add("J")
+			return idx + 3
+		}
+
+		// "Edgar"
+		dm.add("TK")
+
+		return idx + 2
+	case dm.contains(idx, 2, "DT", "DD"):
+		dm.add("T")
+		return idx + 2
+	}
+
+	dm.add("T")
+
+	return idx + 1
+}
+
+// handleG encodes a 'G'
+func (dm *dmEncoder) handleG(idx int) int {
+	next := dm.charAt(idx + 1)
+
+	switch {
+	case next == 'H':
+		return dm.handleGH(idx)
+	case next == 'N':
+		switch {
+		case idx == 1 && dm.isVowel(0) && !dm.slavoGermanic:
+			dm.add2("KN", "N")
+		case !dm.contains(idx+2, 2, "EY") && !dm.slavoGermanic:
+			dm.add2("N", "KN")
+		default:
+			dm.add("KN")
+		}
+
+		return idx + 2
+	case dm.contains(idx+1, 2, "LI") && !dm.slavoGermanic:
+		dm.add2("KL", "L")
+		return idx + 2
+	case idx == 0 &&
+		(next == 'Y' ||
+			dm.contains(idx+1, 2,
+				"ES", "EP", "EB", "EL", "EY",
+				"IB", "IL", "IN", "IE", "EI", "ER")):
+		// -ges-, -gep-, -gel-, -gie- at beginning
+		dm.add2("K", "J")
+		return idx + 2
+	case (dm.contains(idx+1, 2, "ER") || next == 'Y') &&
+		!dm.contains(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!dm.contains(idx-1, 1, "E", "I") &&
+		!dm.contains(idx-1, 3, "RGY", "OGY"):
+		// -ger-, -gy-
+		dm.add2("K", "J")
+		return idx + 2
+	case dm.contains(idx+1, 1, "E", "I", "Y") ||
+		dm.contains(idx-1, 4, "AGGI", "OGGI"):
+		// Italian "biaggi"
+		switch {
+		case dm.startsGermanic() || dm.contains(idx+1, 2, "ET"):
+			// obvious Germanic
+			dm.add("K")
+		case dm.contains(idx+1, 3, "IER"):
+			dm.add("J")
+		default:
+			dm.add2("J", "K")
+		}
+
+		return idx + 2
+	}
+
+	dm.add("K")
+
+	return dm.skipIf(idx, 'G')
+}
+
+// handleGH encodes a "GH"
+func (dm *dmEncoder) handleGH(idx int) int {
+	switch {
+	case idx > 0 && !dm.isVowel(idx-1):
+		dm.add("K")
+	case idx == 0:
+		if dm.charAt(idx+2) == 'I' {
+			dm.add("J")
+		} else {
+			dm.add("K")
+		}
+	case (idx > 1 && dm.contains(idx-2, 1, "B", "H", "D")) ||
+		(idx > 2 && dm.contains(idx-3, 1, "B", "H", "D")) ||
+		(idx > 3 && dm.contains(idx-4, 1, "B", "H")):
+		// Parker's rule (with some further refinements) - "hugh"
+	case idx > 2 && dm.charAt(idx-1) == 'U' &&
+		dm.contains(idx-3, 1, "C", "G", "L", "R", "T"):
+		// "laugh", "McLaughlin", "cough", "gough", "rough", "tough"
+		dm.add("F")
+	case dm.charAt(idx-1) != 'I':
+		dm.add("K")
+	}
+
+	return idx + 2
+}
+
+// handleH encodes an 'H'. It is only kept if it is first or between vowels
+// and is followed by a vowel.
+func (dm *dmEncoder) handleH(idx int) int {
+	if (idx == 0 || dm.isVowel(idx-1)) && dm.isVowel(idx+1) {
+		dm.add("H")
+		return idx + 2
+	}
+
+	return idx + 1
+}
+
+// handleJ encodes a 'J'
+func (dm *dmEncoder) handleJ(idx int) int {
+	if dm.contains(idx, 4, "JOSE") || dm.contains(0, 4, "SAN ") {
+		// obvious Spanish, "Jose", "San Jacinto"
+		if (idx == 0 && dm.charAt(idx+4) == ' ') ||
+			len(dm.val) == 4 ||
+			dm.contains(0, 4, "SAN ") {
+			dm.add("H")
+		} else {
+			dm.add2("J", "H")
+		}
+
+		return idx + 1
+	}
+
+	next := dm.charAt(idx + 1)
+
+	switch {
+	case idx == 0:
+		dm.add2("J", "A")
+	case dm.isVowel(idx-1) && !dm.slavoGermanic &&
+		(next == 'A' || next == 'O'):
+		dm.add2("J", "H")
+	case idx == len(dm.val)-1:
+		dm.add2("J", "")
+	case !dm.contains(idx+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") &&
+		!dm.contains(idx-1, 1, "S", "K", "L"):
+		dm.add("J")
+	}
+
+	return dm.skipIf(idx, 'J')
+}
+
+// handleL encodes an 'L'
+func (dm *dmEncoder) handleL(idx int) int {
+	if dm.charAt(idx+1) == 'L' {
+		if dm.conditionL0(idx) {
+			dm.addPrimary("L")
+		} else {
+			dm.add("L")
+		}
+
+		return idx + 2
+	}
+
+	dm.add("L")
+
+	return idx + 1
+}
+
+// handleP encodes a 'P'
+func (dm *dmEncoder) handleP(idx int) int {
+	if dm.charAt(idx+1) == 'H' {
+		dm.add("F")
+		return idx + 2
+	}
+
+	dm.add("P")
+
+	if dm.contains(idx+1, 1, "P", "B") {
+		return idx + 2
+	}
+
+	return idx + 1
+}
+
+// handleR encodes an 'R'
+func (dm *dmEncoder) handleR(idx int) int {
+	if idx == len(dm.val)-1 && !dm.slavoGermanic &&
+		dm.contains(idx-2, 2, "IE") &&
+		!dm.contains(idx-4, 2, "ME", "MA") {
+		// French e.g. "Rogier"
+		dm.addAlternate("R")
+	} else {
+		dm.add("R")
+	}
+
+	return dm.skipIf(idx, 'R')
+}
+
+// handleS encodes an 'S'
+func (dm *dmEncoder) handleS(idx int) int {
+	switch {
+	case dm.contains(idx-1, 3, "ISL", "YSL"):
+		// "island", "isle", "carlisle", "carlysle"
+		return idx + 1
+	case idx == 0 && dm.contains(idx, 5, "SUGAR"):
+		dm.add2("X", "S")
+		return idx + 1
+	case dm.contains(idx, 2, "SH"):
+		if dm.contains(idx+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// Germanic
+			dm.add("S")
+		} else {
+			dm.add("X")
+		}
+
+		return idx + 2
+	case dm.contains(idx, 3, "SIO", "SIA") || dm.contains(idx, 4, "SIAN"):
+		// Italian and Armenian
+		if dm.slavoGermanic {
+			dm.add("S")
+		} else {
+			dm.add2("S", "X")
+		}
+
+		return idx + 3
+	case (idx == 0 && dm.contains(idx+1, 1, "M", "N", "L", "W")) ||
+		dm.contains(idx+1, 1, "Z"):
+		// German & anglicisations, e.g. "smith" matches "schmidt",
+		// "snider" matches "schneider"; also -sz- in Slavic languages
+		// although in Hungarian it is pronounced "s"
+		dm.add2("S", "X")
+		return dm.skipIf(idx, 'Z')
+	case dm.contains(idx, 2, "SC"):
+		return dm.handleSC(idx)
+	}
+
+	if idx == len(dm.val)-1 && dm.contains(idx-2, 2, "AI", "OI") {
+		// French e.g. "resnais", "artois"
+		dm.addAlternate("S")
+	} else {
+		dm.add("S")
+	}
+
+	if dm.contains(idx+1, 1, "S", "Z") {
+		return idx + 2
+	}
+
+	return idx + 1
+}
+
+// handleSC encodes an "SC"
+func (dm *dmEncoder) handleSC(idx int) int {
+	switch {
+	case dm.charAt(idx+2) == 'H':
+		// Schlesinger's rule
+		switch {
+		case dm.contains(idx+3, 2, "ER", "EN"):
+			// "schermerhorn", "schenker"
+			dm.add2("X", "SK")
+		case dm.contains(idx+3, 2, "OO", "UY", "ED", "EM"):
+			// Dutch origin, e.g. "school", "schooner"
+			dm.add("SK")
+		case idx == 0 && !dm.isVowel(3) && dm.charAt(3) != 'W':
+			dm.add2("X", "S")
+		default:
+			dm.add("X")
+		}
+	case dm.contains(idx+2, 1, "I", "E", "Y"):
+		dm.add("S")
+	default:
+		dm.add("SK")
+	}
+
+	return idx + 3
+}
+
+// handleT encodes a 'T'
+func (dm *dmEncoder) handleT(idx int) int {
+	switch {
+	case dm.contains(idx, 4, "TION"),
+		dm.contains(idx, 3, "TIA", "TCH"):
+		dm.add("X")
+		return idx + 3
+	case dm.contains(idx, 2, "TH"), dm.contains(idx, 3, "TTH"):
+		if dm.contains(idx+2, 2, "OM", "AM") || dm.startsGermanic() {
+			// special case "thomas", "thames" or Germanic
+			dm.add("T")
+		} else {
+			dm.add2("0", "T")
+		}
+
+		return idx + 2
+	}
+
+	dm.add("T")
+
+	if dm.contains(idx+1, 1, "T", "D") {
+		return idx + 2
+	}
+
+	return idx + 1
+}
+
+// handleW encodes a 'W'
+func (dm *dmEncoder) handleW(idx int) int {
+	switch {
+	case dm.contains(idx, 2, "WR"):
+		// can also be in the middle of a word
+		dm.add("R")
+		return idx + 2
+	case idx == 0 && dm.isVowel(idx+1):
+		// "Wasserman" should match "Vasserman"
+		dm.add2("A", "F")
+	case idx == 0 && dm.contains(idx, 2, "WH"):
+		// "Uomo" should match "Womo"
+		dm.add("A")
+	case (idx == len(dm.val)-1 && dm.isVowel(idx-1)) ||
+		dm.contains(idx-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+		dm.contains(0, 3, "SCH"):
+		// "Arnow" should match "Arnoff"
+		dm.addAlternate("F")
+	case dm.contains(idx, 4, "WICZ", "WITZ"):
+		// Polish e.g. "filipowicz"
+		dm.add2("TS", "FX")
+		return idx + 4
+	}
+
+	return idx + 1
+}
+
+// handleX encodes an 'X'
+func (dm *dmEncoder) handleX(idx int) int {
+	if idx == 0 {
+		dm.add("S")
+		return idx + 1
+	}
+
+	if !(idx == len(dm.val)-1 &&
+		(dm.contains(idx-3, 3, "IAU", "EAU") ||
+			dm.contains(idx-2, 2, "AU", "OU"))) {
+		// not French e.g. "breaux"
+		dm.add("KS")
+	}
+
+	if dm.contains(idx+1, 1, "C", "X") {
+		return idx + 2
+	}
+
+	return idx + 1
+}
+
+// handleZ encodes a 'Z'
+func (dm *dmEncoder) handleZ(idx int) int {
+	if dm.charAt(idx+1) == 'H' {
+		// Chinese pinyin e.g. "zhao"
+		dm.add("J")
+		return idx + 2
+	}
+
+	if dm.contains(idx+1, 2, "ZO", "ZI", "ZA") ||
+		(dm.slavoGermanic && idx > 0 && dm.charAt(idx-1) != 'T') {
+		dm.add2("S", "TS")
+	} else {
+		dm.add("S")
+	}
+
+	return dm.skipIf(idx, 'Z')
+}
+
+// conditionC0 returns true if the 'C' should be encoded as a 'K' as in
+// "Chianti" or "Bacher"
+func (dm *dmEncoder) conditionC0(idx int) bool {
+	switch {
+	case dm.contains(idx, 4, "CHIA"):
+		return true
+	case idx <= 1,
+		dm.isVowel(idx - 2),
+		!dm.contains(idx-1, 3, "ACH"):
+		return false
+	}
+
+	c := dm.charAt(idx + 2)
+
+	return (c != 'I' && c != 'E') ||
+		dm.contains(idx-2, 6, "BACHER", "MACHER")
+}
+
+// conditionCH0 returns true if the "CH" at the start of the string has a
+// Greek root
+func (dm *dmEncoder) conditionCH0(idx int) bool {
+	return idx == 0 &&
+		(dm.contains(idx+1, 5, "HARAC", "HARIS") ||
+			dm.contains(idx+1, 3, "HOR", "HYM", "HIA", "HEM")) &&
+		!dm.contains(0, 5, "CHORE")
+}
+
+// conditionCH1 returns true if the "CH" should be encoded as 'K'
+func (dm *dmEncoder) conditionCH1(idx int) bool {
+	return dm.startsGermanic() ||
+		dm.contains(idx-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		dm.contains(idx+2, 1, "T", "S") ||
+		((dm.contains(idx-1, 1, "A", "O", "U", "E") || idx == 0) &&
+			(dm.contains(idx+2, 1,
+				"L", "R", "N", "M", "B", "H", "F", "V", "W", " ") ||
+				idx+1 == len(dm.val)-1))
+}
+
+// conditionL0 returns true if the "LL" is Spanish and so the alternate
+// code should not be given an 'L'
+func (dm *dmEncoder) conditionL0(idx int) bool {
+	n := len(dm.val)
+
+	if idx == n-3 && dm.contains(idx-1, 4, "ILLO", "ILLA", "ALLE") {
+		return true
+	}
+
+	return (dm.contains(n-2, 2, "AS", "OS") ||
+		dm.contains(n-1, 1, "A", "O")) &&
+		dm.contains(idx-1, 4, "ALLE")
+}
+
+// conditionM0 returns true if the rune following the 'M' should be skipped
+func (dm *dmEncoder) conditionM0(idx int) bool {
+	if dm.charAt(idx+1) == 'M' {
+		return true
+	}
+
+	// "dumb", "thumb"
+	return dm.contains(idx-1, 3, "UMB") &&
+		(idx+1 == len(dm.val)-1 || dm.contains(idx+2, 2, "ER"))
+}
+
+// MetaphoneAlgo encapsulates the details needed to provide a Double
+// Metaphone distance. Each string is converted into its primary and
+// alternate Double Metaphone codes and every pairing of the codes of the
+// two strings is compared; the distance is the smallest of these. If there
+// is no inner Algo then the distance is 0 if any pair of codes is the same
+// and 1 otherwise; otherwise it is the smallest of the inner Algo's
+// distances between the codes.
+type MetaphoneAlgo struct {
+	inner Algo
+}
+
+// NewMetaphoneAlgo returns a new MetaphoneAlgo. The inner Algo may be nil
+// in which case some pair of codes must be identical for the strings to
+// match.
+func NewMetaphoneAlgo(inner Algo) *MetaphoneAlgo {
+	return &MetaphoneAlgo{inner: inner}
+}
+
+// Name returns the algorithm name
+func (MetaphoneAlgo) Name() string {
+	return AlgoNameDoubleMetaphone
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a MetaphoneAlgo) Desc() string {
+	if a.inner == nil {
+		return ""
+	}
+
+	return "Inner: " + algoDesc(a.inner)
+}
+
+// Dist for a MetaphoneAlgo will calculate the smallest distance between the
+// Double Metaphone codes of the two strings
+func (a MetaphoneAlgo) Dist(s1, s2 string) float64 {
+	if a.inner == nil {
+		return a.dist(s1, s2, nil)
+	}
+
+	return a.dist(s1, s2, a.inner.Dist)
+}
+
+// NormalizedDist for a MetaphoneAlgo is as for Dist but uses the normalised
+// distances given by the inner Algo
+func (a MetaphoneAlgo) NormalizedDist(s1, s2 string) float64 {
+	if a.inner == nil {
+		return a.dist(s1, s2, nil)
+	}
+
+	return a.dist(s1, s2, normalizedDistFunc(a.inner))
+}
+
+// dist calculates the distance using the given func to calculate the
+// distances between the codes. If the func is nil the distance is 0 if any
+// pair of codes is the same and 1 otherwise.
+func (a MetaphoneAlgo) dist(s1, s2 string, codeDist distFunc) float64 {
+	codes1 := DoubleMetaphoneEncoder{}.EncodeAll(s1)
+	codes2 := DoubleMetaphoneEncoder{}.EncodeAll(s2)
+
+	if codeDist == nil {
+		for _, c1 := range codes1 {
+			if slices.Contains(codes2, c1) {
+				return 0
+			}
+		}
+
+		return 1
+	}
+
+	dist := math.Inf(1)
+
+	for _, c1 := range codes1 {
+		for _, c2 := range codes2 {
+			dist = min(dist, codeDist(c1, c2))
+		}
+	}
+
+	return dist
+}
@@ -0,0 +1,133 @@
+package strdist_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestDoubleMetaphone(t *testing.T) {
+	testCases := []struct {
+		s            string
+		expPrimary   string
+		expAlternate string
+	}{
+		{s: "", expPrimary: "", expAlternate: ""},
+		{s: "  ", expPrimary: "", expAlternate: ""},
+		{s: "Smith", expPrimary: "SM0", expAlternate: "XMT"},
+		{s: "Schmidt", expPrimary: "XMT", expAlternate: "SMT"},
+		{s: "Michael", expPrimary: "MKL", expAlternate: "MXL"},
+		{s: "Arnow", expPrimary: "ARN", expAlternate: "ARNF"},
+		{s: "Caesar", expPrimary: "SSR", expAlternate: "SSR"},
+		{s: "Jose", expPrimary: "HS", expAlternate: "HS"},
+		{s: "Jones", expPrimary: "JNS", expAlternate: "ANS"},
+		{s: "Wasserman", expPrimary: "ASRM", expAlternate: "FSRM"},
+		{s: "Zhao", expPrimary: "J", expAlternate: "J"},
+		{s: "Filipowicz", expPrimary: "FLPT", expAlternate: "FLPF"},
+		{s: "Czerny", expPrimary: "SRN", expAlternate: "XRN"},
+		{s: "Xavier", expPrimary: "SF", expAlternate: "SFR"},
+		{s: "Cabrillo", expPrimary: "KPRL", expAlternate: "KPR"},
+		{s: "Schenker", expPrimary: "XNKR", expAlternate: "SKNK"},
+		{s: "School", expPrimary: "SKL", expAlternate: "SKL"},
+		{s: "Dumb", expPrimary: "TM", expAlternate: "TM"},
+		{s: "Gnome", expPrimary: "NM", expAlternate: "NM"},
+		{s: "Laugh", expPrimary: "LF", expAlternate: "LF"},
+		{s: "Hugh", expPrimary: "H", expAlternate: "H"},
+		{s: "Accident", expPrimary: "AKST", expAlternate: "AKST"},
+		{s: "Breaux", expPrimary: "PR", expAlternate: "PR"},
+		{s: "Muñoz", expPrimary: "MNS", expAlternate: "MNS"},
+		{s: "smith", expPrimary: "SM0", expAlternate: "XMT"},
+	}
+
+	for _, tc := range testCases {
+		p, a := strdist.DoubleMetaphone(tc.s)
+		testhelper.DiffString(t, tc.s, "primary code", p, tc.expPrimary)
+		testhelper.DiffString(t, tc.s, "alternate code", a, tc.expAlternate)
+	}
+
+	checkPhoneticCodes(t, strdist.DoubleMetaphoneEncoder{}, []phoneticTestCase{
+		{s: "Schmidt", expCode: "XMT"},
+		{s: "Smith", expCode: "SM0"},
+	})
+
+	enc := strdist.DoubleMetaphoneEncoder{}
+	testhelper.DiffStringSlice(t, "Smith", "all codes",
+		enc.EncodeAll("Smith"), []string{"SM0", "XMT"})
+	testhelper.DiffStringSlice(t, "Caesar", "all codes",
+		enc.EncodeAll("Caesar"), []string{"SSR"})
+}
+
+func TestMetaphoneAlgo(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		algo    *strdist.MetaphoneAlgo
+		a, b    string
+		expDist float64
+		expDesc string
+	}{
+		{
+			ID:      testhelper.MkID("primary codes match"),
+			algo:    strdist.NewMetaphoneAlgo(nil),
+			a:       "Jones",
+			b:       "Johns",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("primary matches alternate"),
+			algo:    strdist.NewMetaphoneAlgo(nil),
+			a:       "Smith",
+			b:       "Schmidt",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("alternate codes match"),
+			algo:    strdist.NewMetaphoneAlgo(nil),
+			a:       "Wasserman",
+			b:       "Vasserman",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("no codes match"),
+			algo:    strdist.NewMetaphoneAlgo(nil),
+			a:       "Smith",
+			b:       "Jones",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("inner algo, close codes"),
+			algo:    strdist.NewMetaphoneAlgo(strdist.LevenshteinAlgo{}),
+			a:       "Smith",
+			b:       "Smithers",
+			expDist: 1,
+			expDesc: "Inner: " + strdist.AlgoNameLevenshtein,
+		},
+		{
+			ID:      testhelper.MkID("inner algo, best pair chosen"),
+			algo:    strdist.NewMetaphoneAlgo(strdist.LevenshteinAlgo{}),
+			a:       "Schmidt",
+			b:       "Smithson",
+			expDist: 1,
+			expDesc: "Inner: " + strdist.AlgoNameLevenshtein,
+		},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffFloat(t, tc.IDStr(), "Dist",
+			tc.algo.Dist(tc.a, tc.b), tc.expDist, 0)
+		testhelper.DiffFloat(t, tc.IDStr(), "Dist (reversed)",
+			tc.algo.Dist(tc.b, tc.a), tc.expDist, 0)
+		testhelper.DiffString(t, tc.IDStr(), "Name",
+			tc.algo.Name(), strdist.AlgoNameDoubleMetaphone)
+		testhelper.DiffString(t, tc.IDStr(), "Desc",
+			tc.algo.Desc(), tc.expDesc)
+	}
+}
+
+func TestMetaphoneFinder(t *testing.T) {
+	pop := []string{"Smithers", "Jones", "Schmidt", "Smyth", "Schmitt"}
+
+	f := strdist.DefaultFinders[strdist.CaseBlindAlgoNameDoubleMetaphone]
+	finderChecker(t, "double Metaphone", "default finder",
+		"Smith", pop, f, []string{"Smyth", "Schmidt", "Schmitt"})
+}
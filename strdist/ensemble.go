@@ -0,0 +1,279 @@
+package strdist
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// EnsembleMode specifies how the distances from the components of an
+// EnsembleAlgo are combined
+type EnsembleMode int
+
+// These are the available modes.
+//
+// EnsembleWeightedMean gives the weighted mean of the (scaled) component
+// distances.
+//
+// EnsembleMin gives the smallest of the (scaled) component distances; the
+// weights are ignored other than that components with a zero weight are
+// not used. This finds strings that any of the components regards as
+// close.
+//
+// EnsembleRankFusion ranks the population by each component's distance and
+// gives the weighted mean of the normalised ranks. The normalised rank is
+// the number of strings that the component regards as closer divided by
+// the number of strings ranked so it is 0 for the best string and
+// approaches 1 for the worst. Only those strings whose weighted mean
+// distance (as for EnsembleWeightedMean) is within the Finder's threshold
+// are ranked so the threshold should be chosen for that distance; the
+// ranks then decide the order of the strings found. Ranking needs the whole
+// population and so this is only possible when the EnsembleAlgo is used
+// directly by a Finder; the Dist method gives the weighted mean distance
+// and a FinderOf or a DistanceMatrix cannot be created with an
+// EnsembleRankFusion Algo.
+const (
+	EnsembleWeightedMean EnsembleMode = iota
+	EnsembleMin
+	EnsembleRankFusion
+)
+
+// String returns a string describing the mode
+func (mode EnsembleMode) String() string {
+	switch mode {
+	case EnsembleWeightedMean:
+		return "weighted mean"
+	case EnsembleMin:
+		return "min"
+	case EnsembleRankFusion:
+		return "rank fusion"
+	}
+
+	return fmt.Sprintf("EnsembleMode(%d)", int(mode))
+}
+
+// Check returns a non-nil error if the mode is not one of the available
+// values
+func (mode EnsembleMode) Check() error {
+	if mode < EnsembleWeightedMean || mode > EnsembleRankFusion {
+		return errors.New("unknown ensemble mode: " + mode.String())
+	}
+
+	return nil
+}
+
+// EnsembleComponent records an Algo to be used in an EnsembleAlgo and how
+// its distances are to be treated
+type EnsembleComponent struct {
+	Algo Algo
+	// Weight gives the relative importance of the component. It must be
+	// >= 0 and at least one component must have a non-zero weight.
+	Weight float64
+	// Scale is used to normalise the component's distances so that they
+	// are comparable with those of the other components. If it is greater
+	// than zero then the distance is divided by the Scale and capped at 1,
+	// so a Levenshtein distance might be given a Scale equal to its
+	// Finder's threshold. If it is zero the distance is used unchanged;
+	// this is suitable for algorithms giving distances between 0 and 1.
+	Scale float64
+}
+
+// desc returns a string describing the component
+func (ec EnsembleComponent) desc() string {
+	s := fmt.Sprintf("%s [Weight: %g", algoDesc(ec.Algo), ec.Weight)
+	if ec.Scale > 0 {
+		s += fmt.Sprintf(" Scale: %g", ec.Scale)
+	}
+
+	return s + "]"
+}
+
+// dist returns the scaled distance between the strings
+func (ec EnsembleComponent) dist(s1, s2 string) float64 {
+	d := ec.Algo.Dist(s1, s2)
+	if ec.Scale > 0 {
+		d = min(d/ec.Scale, 1)
+	}
+
+	return d
+}
+
+// normalizedDist returns the normalised distance between the strings
+func (ec EnsembleComponent) normalizedDist(s1, s2 string) float64 {
+	return normalizedDistFunc(ec.Algo)(s1, s2)
+}
+
+// EnsembleAlgo encapsulates the details needed to combine the distances
+// from several Algos into a single distance. This allows, for instance, a
+// Levenshtein distance (good at finding typing mistakes) to be combined
+// with a cosine distance (good at finding reordered fragments).
+type EnsembleAlgo struct {
+	mode        EnsembleMode
+	comps       []EnsembleComponent
+	totalWeight float64
+}
+
+// NewEnsembleAlgo checks that the parameters are valid and returns a new
+// EnsembleAlgo if they are. There must be at least one component, each
+// component must have an Algo, the weights and scales must be >= 0 and at
+// least one weight must be greater than zero.
+func NewEnsembleAlgo(mode EnsembleMode, comps ...EnsembleComponent,
+) (*EnsembleAlgo, error) {
+	if err := mode.Check(); err != nil {
+		return nil, err
+	}
+
+	if len(comps) == 0 {
+		return nil, errors.New("there must be at least one component")
+	}
+
+	var totalWeight float64
+
+	for i, c := range comps {
+		if c.Algo == nil {
+			return nil, fmt.Errorf("component %d has no Algo", i)
+		}
+
+		if !(c.Weight >= 0) || math.IsInf(c.Weight, 1) {
+			return nil, fmt.Errorf(
+				"the Weight (%f) of component %d must be finite and >= 0",
+				c.Weight, i)
+		}
+
+		if !(c.Scale >= 0) || math.IsInf(c.Scale, 1) {
+			return nil, fmt.Errorf(
+				"the Scale (%f) of component %d must be finite and >= 0",
+				c.Scale, i)
+		}
+
+		totalWeight += c.Weight
+	}
+
+	if totalWeight == 0 {
+		return nil, errors.New("at least one component Weight must be > 0")
+	}
+
+	return &EnsembleAlgo{
+		mode:        mode,
+		comps:       slices.Clone(comps),
+		totalWeight: totalWeight,
+	}, nil
+}
+
+// NewEnsembleAlgoOrPanic returns a new EnsembleAlgo. It will panic if the
+// EnsembleAlgo cannot be created without errors.
+func NewEnsembleAlgoOrPanic(mode EnsembleMode, comps ...EnsembleComponent,
+) *EnsembleAlgo {
+	a, err := NewEnsembleAlgo(mode, comps...)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// Name returns the algorithm name
+func (EnsembleAlgo) Name() string {
+	return AlgoNameEnsemble
+}
+
+// Desc returns a string describing the algorithm configuration. This gives
+// the mode and lists the components.
+func (a EnsembleAlgo) Desc() string {
+	descs := make([]string, 0, len(a.comps))
+	for _, c := range a.comps {
+		descs = append(descs, c.desc())
+	}
+
+	return "Mode: " + a.mode.String() +
+		" Components: " + strings.Join(descs, ", ")
+}
+
+// Dist for an EnsembleAlgo will combine the distances between the two
+// strings from each of the components, as given by the mode. Note that for
+// the EnsembleRankFusion mode this gives the weighted mean distance.
+func (a EnsembleAlgo) Dist(s1, s2 string) float64 {
+	return a.combine(s1, s2, EnsembleComponent.dist)
+}
+
+// NormalizedDist for an EnsembleAlgo is as for Dist but uses the normalised
+// distances given by the components (their Scales are not used)
+func (a EnsembleAlgo) NormalizedDist(s1, s2 string) float64 {
+	return a.combine(s1, s2, EnsembleComponent.normalizedDist)
+}
+
+// combine combines the distances between the two strings from each of the
+// components, as given by the mode, using compDist to calculate them
+func (a EnsembleAlgo) combine(s1, s2 string,
+	compDist func(c EnsembleComponent, s1, s2 string) float64,
+) float64 {
+	if a.mode == EnsembleMin {
+		dist := math.Inf(1)
+
+		for _, c := range a.comps {
+			if c.Weight > 0 {
+				dist = min(dist, compDist(c, s1, s2))
+			}
+		}
+
+		return dist
+	}
+
+	var dist float64
+
+	for _, c := range a.comps {
+		if c.Weight > 0 {
+			dist += c.Weight * compDist(c, s1, s2)
+		}
+	}
+
+	return dist / a.totalWeight
+}
+
+// ranksPopulation returns true if the mode is EnsembleRankFusion
+func (a EnsembleAlgo) ranksPopulation() bool {
+	return a.mode == EnsembleRankFusion
+}
+
+// rankDists returns the rank fusion distances between the target string and
+// each of the population strings, in population order. If normalized is
+// true the strings are ranked by the normalised component distances.
+func (a EnsembleAlgo) rankDists(s string, pop []string, normalized bool,
+) []float64 {
+	compDist := EnsembleComponent.dist
+	if normalized {
+		compDist = EnsembleComponent.normalizedDist
+	}
+
+	fused := make([]float64, len(pop))
+	dists := make([]float64, len(pop))
+	sorted := make([]float64, len(pop))
+	n := float64(len(pop))
+
+	for _, c := range a.comps {
+		if c.Weight == 0 {
+			continue
+		}
+
+		for i, p := range pop {
+			dists[i] = compDist(c, s, p)
+		}
+
+		copy(sorted, dists)
+		slices.Sort(sorted)
+
+		for i, d := range dists {
+			// the number of population strings closer than this one
+			closer, _ := slices.BinarySearch(sorted, d)
+			fused[i] += c.Weight * float64(closer) / n
+		}
+	}
+
+	for i := range fused {
+		fused[i] /= a.totalWeight
+	}
+
+	return fused
+}
@@ -0,0 +1,221 @@
+package strdist_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewEnsembleAlgo(t *testing.T) {
+	lev := strdist.LevenshteinAlgo{}
+	ham := strdist.HammingAlgo{}
+
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		mode    strdist.EnsembleMode
+		comps   []strdist.EnsembleComponent
+		expDesc string
+	}{
+		{
+			ID:   testhelper.MkID("good"),
+			mode: strdist.EnsembleWeightedMean,
+			comps: []strdist.EnsembleComponent{
+				{Algo: lev, Weight: 2, Scale: 5},
+				{Algo: ham, Weight: 1},
+			},
+			expDesc: "Mode: weighted mean Components:" +
+				" Levenshtein [Weight: 2 Scale: 5]," +
+				" Hamming [Weight: 1]",
+		},
+		{
+			ID:   testhelper.MkID("bad mode"),
+			mode: strdist.EnsembleMode(99),
+			comps: []strdist.EnsembleComponent{
+				{Algo: lev, Weight: 1},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"unknown ensemble mode: EnsembleMode(99)"),
+		},
+		{
+			ID:     testhelper.MkID("no components"),
+			ExpErr: testhelper.MkExpErr("there must be at least one component"),
+		},
+		{
+			ID: testhelper.MkID("no Algo"),
+			comps: []strdist.EnsembleComponent{
+				{Algo: lev, Weight: 1},
+				{Weight: 1},
+			},
+			ExpErr: testhelper.MkExpErr("component 1 has no Algo"),
+		},
+		{
+			ID: testhelper.MkID("bad Weight"),
+			comps: []strdist.EnsembleComponent{
+				{Algo: lev, Weight: -1},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"the Weight (-1.000000) of component 0" +
+					" must be finite and >= 0"),
+		},
+		{
+			ID: testhelper.MkID("NaN Scale"),
+			comps: []strdist.EnsembleComponent{
+				{Algo: lev, Weight: 1, Scale: math.NaN()},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"the Scale (NaN) of component 0 must be finite and >= 0"),
+		},
+		{
+			ID: testhelper.MkID("zero Weights"),
+			comps: []strdist.EnsembleComponent{
+				{Algo: lev},
+				{Algo: ham},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"at least one component Weight must be > 0"),
+		},
+	}
+
+	for _, tc := range testCases {
+		a, err := strdist.NewEnsembleAlgo(tc.mode, tc.comps...)
+		if testhelper.CheckExpErr(t, err, tc) && err == nil {
+			testhelper.DiffString(t, tc.IDStr(), "Name",
+				a.Name(), strdist.AlgoNameEnsemble)
+			testhelper.DiffString(t, tc.IDStr(), "Desc", a.Desc(), tc.expDesc)
+		}
+	}
+}
+
+func TestEnsembleDist(t *testing.T) {
+	comps := []strdist.EnsembleComponent{
+		{Algo: strdist.LevenshteinAlgo{}, Weight: 3, Scale: 4},
+		{Algo: strdist.HammingAlgo{}, Weight: 1, Scale: 4},
+		{Algo: strdist.LCSAlgo{}, Weight: 0},
+	}
+
+	testCases := []struct {
+		testhelper.ID
+		mode    strdist.EnsembleMode
+		a, b    string
+		expDist float64
+	}{
+		{
+			ID:      testhelper.MkID("weighted mean, identical"),
+			mode:    strdist.EnsembleWeightedMean,
+			a:       "abcd",
+			b:       "abcd",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("weighted mean"),
+			mode:    strdist.EnsembleWeightedMean,
+			a:       "abcd",
+			b:       "bcda",
+			expDist: (3*0.5 + 1*1) / 4,
+		},
+		{
+			ID:      testhelper.MkID("weighted mean, scaled distance capped"),
+			mode:    strdist.EnsembleWeightedMean,
+			a:       "abcd",
+			b:       "wxyz12",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("min"),
+			mode:    strdist.EnsembleMin,
+			a:       "abcd",
+			b:       "bcda",
+			expDist: 0.5,
+		},
+		{
+			ID:      testhelper.MkID("rank fusion gives the weighted mean"),
+			mode:    strdist.EnsembleRankFusion,
+			a:       "abcd",
+			b:       "bcda",
+			expDist: (3*0.5 + 1*1) / 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		a := strdist.NewEnsembleAlgoOrPanic(tc.mode, comps...)
+		testhelper.DiffFloat(t, tc.IDStr(), "Dist",
+			a.Dist(tc.a, tc.b), tc.expDist, 1e-12)
+	}
+}
+
+func TestEnsembleRankFusion(t *testing.T) {
+	a := strdist.NewEnsembleAlgoOrPanic(strdist.EnsembleRankFusion,
+		strdist.EnsembleComponent{Algo: strdist.LevenshteinAlgo{}, Weight: 3},
+		strdist.EnsembleComponent{Algo: strdist.HammingAlgo{}, Weight: 1})
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: 2.5, MinStrLength: 3}, a)
+
+	// Levenshtein distances: 0, 2, 1, 4 and Hamming distances: 0, 4, 1, 4
+	// so the weighted mean distances are 0, 2.5, 1, 4 and "wxyz" is too
+	// far away to be ranked. Of the rest the numbers closer are 0, 2, 1
+	// for both components. "ab" is too short to be ranked.
+	pop := []string{"abcd", "bcda", "xbcd", "wxyz", "ab"}
+	exp := []strdist.StrDist{
+		{Str: "abcd", Dist: 0},
+		{Str: "xbcd", Dist: (3.0*1 + 1*1) / 4 / 3},
+		{Str: "bcda", Dist: (3.0*2 + 1*2) / 4 / 3},
+	}
+
+	testhelper.DiffSlice(t, "rank fusion", "FindLike",
+		f.FindLike("abcd", pop...), exp)
+
+	testhelper.DiffSlice(t, "rank fusion", "FindLikeSeq",
+		f.CollectSorted("abcd", f.FindLikeSeq("abcd", slices.Values(pop))),
+		exp)
+
+	var keys []int
+	for k := range strdist.FindLikeSeq2(f, "abcd", slices.All(pop)) {
+		keys = append(keys, k)
+	}
+
+	testhelper.DiffSlice(t, "rank fusion", "FindLikeSeq2 keys",
+		keys, []int{0, 1, 2})
+
+	// the best ranked string is not found if it is too far away
+	fStrict := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: 0.1, MinStrLength: 3}, a)
+
+	testhelper.DiffInt(t, "rank fusion", "FindLike - unlike strings",
+		len(fStrict.FindLike("hello", "zzzzzzzzqqq", "wwwwwwwwwwwww")), 0)
+
+	_, unique, _ := fStrict.FindBest("hello", "zzzzzzzzqqq")
+	testhelper.DiffBool(t, "rank fusion", "FindBest - unique", unique, false)
+}
+
+func TestEnsembleRankFusionRejected(t *testing.T) {
+	a := strdist.NewEnsembleAlgoOrPanic(strdist.EnsembleRankFusion,
+		strdist.EnsembleComponent{Algo: strdist.LevenshteinAlgo{}, Weight: 1})
+	fc := strdist.FinderConfig{Threshold: 1}
+
+	_, err := strdist.NewFinderOf(fc, a, func(s string) string { return s })
+	testhelper.CheckExpErrWithID(t, "FinderOf", err,
+		testhelper.MkExpErr("the ensemble Algo cannot be used by a FinderOf"))
+
+	_, err = strdist.NewDistanceMatrix(fc, a, "abc", "abd")
+	testhelper.CheckExpErrWithID(t, "DistanceMatrix", err,
+		testhelper.MkExpErr(
+			"the ensemble Algo cannot be used by a DistanceMatrix"))
+}
+
+func TestEnsembleFinder(t *testing.T) {
+	pop := []string{
+		"New York Mets",
+		"new yrok mets",
+		"Boston Red Sox",
+		"NY Mets",
+	}
+
+	f := strdist.DefaultFinders[strdist.CaseBlindAlgoNameEnsemble]
+	finderChecker(t, "ensemble", "default finder",
+		"New York Mets", pop, f,
+		[]string{"New York Mets", "new yrok mets"})
+}
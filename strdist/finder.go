@@ -1,8 +1,11 @@
 package strdist
 
 import (
-	"sort"
+	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 // Finder records the parameters of the finding algorithm
@@ -18,12 +21,20 @@ type Finder struct {
 
 // NewFinder checks that the parameters are valid and creates a new
 // Finder if they are. The minLen and threshold limit must each be >=
-// 0. A zero threshold wil require an exact match.
+// 0. A zero threshold wil require an exact match. If the FinderConfig is
+// Normalized then the algo must be a NormalizedAlgo.
 func NewFinder(fc FinderConfig, algo Algo) (*Finder, error) {
 	if err := fc.Check(); err != nil {
 		return nil, err
 	}
 
+	if _, ok := algo.(NormalizedAlgo); fc.Normalized && algo != nil && !ok {
+		return nil, fmt.Errorf(
+			"the %s Algo must be a NormalizedAlgo"+
+				" when distances are Normalized",
+			algo.Name())
+	}
+
 	return &Finder{
 		FinderConfig: fc,
 		Algo:         algo,
@@ -73,22 +84,30 @@ func (f *Finder) FindLike(s string, pop ...string) []StrDist {
 		return nil
 	}
 
-	s = f.prepStr(s)
-	if len(s) < f.MinStrLength {
+	s, ok := f.prepTarget(s)
+	if !ok {
 		return nil
 	}
 
-	dists := make([]StrDist, 0, len(pop))
+	if pr, ok := popRanker(f.Algo); ok {
+		idxs, ranks := f.rankPop(pr, s, pop)
 
-	for _, pOrig := range pop {
-		p := f.prepStr(pOrig)
-
-		if len(p) < f.MinStrLength {
-			continue
+		dists := make([]StrDist, 0, len(idxs))
+		for i, idx := range idxs {
+			dists = append(dists, StrDist{Str: pop[idx], Dist: ranks[i]})
 		}
 
-		d := f.Algo.Dist(s, p)
-		if d > f.Threshold {
+		sortByDist(dists, f.cmpFunc(s),
+			func(sd StrDist) StrDist { return sd })
+
+		return dists
+	}
+
+	dists := make([]StrDist, 0, len(pop))
+
+	for _, pOrig := range pop {
+		d, ok := f.dist(s, pOrig)
+		if !ok {
 			continue
 		}
 
@@ -98,13 +117,139 @@ func (f *Finder) FindLike(s string, pop ...string) []StrDist {
 		})
 	}
 
-	lt := lessThanFunc(len(s))
-
-	sort.Slice(dists, func(i, j int) bool { return lt(dists[i], dists[j]) })
+	sortByDist(dists, f.cmpFunc(s), func(sd StrDist) StrDist { return sd })
 
 	return dists
 }
 
+// populationRanker is implemented by Algos whose distances, as used by a
+// Finder, are ranks relative to the rest of the population (see
+// EnsembleRankFusion).
+type populationRanker interface {
+	// ranksPopulation returns true if the Algo is currently giving
+	// population-relative distances
+	ranksPopulation() bool
+	// rankDists returns the rank distances between the string and each of
+	// the population strings, in population order. If normalized is true
+	// then the strings are ranked by their normalised distances.
+	rankDists(s string, pop []string, normalized bool) []float64
+}
+
+// popRanker returns the Algo as a populationRanker and true if it gives
+// population-relative distances
+func popRanker(algo Algo) (populationRanker, bool) {
+	pr, ok := algo.(populationRanker)
+
+	return pr, ok && pr.ranksPopulation()
+}
+
+// rankPop returns the indexes of those strings in the population which are
+// similar to the (prepared) target string and their population-relative
+// distances from the populationRanker. Only those strings whose distance, as
+// given by the Algo's Dist (or NormalizedDist) method, is within the
+// threshold are ranked; the ranks alone cannot show whether a string is
+// similar to the target as the best ranked string has a zero distance
+// however unlike the target it is.
+func (f *Finder) rankPop(pr populationRanker, s string, pop []string,
+) ([]int, []float64) {
+	idxs := make([]int, 0, len(pop))
+	prepped := make([]string, 0, len(pop))
+
+	for i, pOrig := range pop {
+		if _, ok := f.dist(s, pOrig); ok {
+			idxs = append(idxs, i)
+			prepped = append(prepped, f.prepStr(pOrig))
+		}
+	}
+
+	return idxs, pr.rankDists(s, prepped, f.Normalized)
+}
+
+// prepTarget converts the target string according to the FinderConfig. It
+// returns false if the string is too short or too long to be compared.
+func (f *Finder) prepTarget(s string) (string, bool) {
+	s = f.prepStr(s)
+
+	return s, f.lenOK(utf8.RuneCountInString(s))
+}
+
+// prepCandidate converts the population string according to the
+// FinderConfig. It returns false if the string is too short or too long to
+// be compared or if its length is too different from that of the target
+// (sLen).
+func (f *Finder) prepCandidate(sLen int, pOrig string) (string, bool) {
+	p := f.prepStr(pOrig)
+	pLen := utf8.RuneCountInString(p)
+
+	return p, f.lenOK(pLen) && f.lensComparable(sLen, pLen)
+}
+
+// dist returns the distance between the target string (which must already
+// have been prepared by prepTarget) and the population string. It returns
+// false if the population string cannot be compared (see prepCandidate) or
+// if the distance exceeds the threshold or is not a number (NaN).
+func (f *Finder) dist(s, pOrig string) (float64, bool) {
+	p, ok := f.prepCandidate(utf8.RuneCountInString(s), pOrig)
+	if !ok {
+		return 0, false
+	}
+
+	var d float64
+	if f.Normalized {
+		d = normalizedDistFunc(f.Algo)(s, p)
+	} else {
+		d = f.Algo.Dist(s, p)
+	}
+
+	if !(d <= f.threshold(s)) {
+		return 0, false
+	}
+
+	return d, true
+}
+
+// sortByDist sorts the slice into the order given by the cmp func (see
+// cmpFunc); the sort is stable. The toStrDist func gives the StrDist for
+// each element.
+func sortByDist[E any](s []E, cmp func(sd1, sd2 StrDist) int,
+	toStrDist func(E) StrDist,
+) {
+	slices.SortStableFunc(s, func(e1, e2 E) int {
+		return cmp(toStrDist(e1), toStrDist(e2))
+	})
+}
+
+// FindBest returns the StrDist for the string in the population (pop) which
+// is most similar to the string (s), whether it is the unique best match and
+// the gap between its distance and that of the runner-up. The best match
+// and the runner-up are as given by FindLike; copies of the best string in
+// the population are not regarded as runners-up. If there is no runner-up
+// the gap is +Inf. The best match is unique if the gap is greater than zero
+// and at least the MinMargin. If there are no similar strings then the
+// StrDist is the zero value, the match is not unique and the gap is zero.
+//
+// This can be used, for instance, to automatically correct a mistyped value
+// only when the correction is unambiguous.
+func (f *Finder) FindBest(s string, pop ...string,
+) (best StrDist, unique bool, gap float64) {
+	dists := f.FindLike(s, pop...)
+	if len(dists) == 0 {
+		return StrDist{}, false, 0
+	}
+
+	best = dists[0]
+	gap = math.Inf(1)
+
+	for _, sd := range dists[1:] {
+		if sd.Str != best.Str {
+			gap = sd.Dist - best.Dist
+			break
+		}
+	}
+
+	return best, gap > 0 && gap >= f.MinMargin, gap
+}
+
 // FindStrLike returns those strings in the population (pop) which are
 // similar to the string (s). Similarity is as for the Find func.
 func (f *Finder) FindStrLike(s string, pop ...string) []string {
@@ -1,6 +1,9 @@
 package strdist
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // DfltMinStrLength is the default value for the minimum string length
 const DfltMinStrLength = 3
@@ -11,20 +14,57 @@ type FinderConfig struct {
 	// Threshold limits the distance that a string must have for the Finder
 	// to recognise it as a match. The distance must be <= the threshold
 	Threshold float64
-	// MinStrLength is the shortest string that will be compared against
-	// other strings. The problem with trying to find similar strings to very
-	// short targets is that they can match with a lot of not obviously
-	// similar alternatives. For instance a match for a single character
-	// string might be every other single character string in the
+	// ThresholdPolicy, if set, gives the threshold to be used for each
+	// target string and the Threshold is ignored. This allows the
+	// threshold to vary with the length of the target. If distances are
+	// Normalized the policy must be bounded with a maximum threshold <= 1.
+	ThresholdPolicy ThresholdPolicy
+	// MinStrLength is the shortest string (in runes) that will be compared
+	// against other strings. The problem with trying to find similar
+	// strings to very short targets is that they can match with a lot of
+	// not obviously similar alternatives. For instance a match for a single
+	// character string might be every other single character string in the
 	// population. For a number of use cases this is not particularly
 	// helpful.
 	MinStrLength int
+	// MaxStrLength, if greater than zero, is the longest string (in runes)
+	// that will be compared against other strings.
+	MaxStrLength int
+	// MaxLenRatio, if greater than zero, is the largest ratio of the
+	// lengths (in runes) of the longer and shorter of the target and a
+	// population string for them to be compared. If it is set it must be
+	// at least 1.
+	MaxLenRatio float64
+	// MaxLenDiff, if greater than zero, is the largest difference between
+	// the lengths (in runes) of the target and a population string for them
+	// to be compared.
+	//
+	// The length filters are checked before the distance is calculated.
+	// The Levenshtein distance, for instance, is at least the difference
+	// in lengths so setting MaxLenDiff to the Threshold discards strings
+	// that cannot match without calculating their distance.
+	MaxLenDiff int
 	// MapToLowerCase is set to indicate that the string should be mapped to
 	// a lower-case equivalent before calculating the distance.
 	MapToLowerCase bool
 	// StripRunes is a set of runes (unicode characters in a string) to be
 	// removed from the string before calculating the distance.
 	StripRunes string
+	// MinMargin is the amount by which the distance of the best match found
+	// by the Finder's FindBest method must be smaller than that of the
+	// runner-up for the best match to be regarded as unique. A zero value
+	// means that the best match need only be strictly better.
+	MinMargin float64
+	// TieBreaker orders the strings found by the Finder which have the same
+	// distance from the target. If it is not set the DefaultTieBreaker is
+	// used.
+	TieBreaker TieBreaker
+	// Normalized is set to indicate that the Finder should use the
+	// normalised distances given by the Algo (which must be a
+	// NormalizedAlgo). These are between 0 and 1 and are broadly comparable
+	// between algorithms so the same Threshold can be used whichever Algo
+	// is chosen. The Threshold must be <= 1.
+	Normalized bool
 }
 
 // Check checks that the FinderConfig has valid values and returns an error
@@ -41,15 +81,165 @@ func (fc FinderConfig) Check() error {
 			fc.MinStrLength)
 	}
 
+	if fc.MaxStrLength < 0 {
+		return fmt.Errorf(
+			"FinderConfig: the maximum string length (%d) must be >= 0",
+			fc.MaxStrLength)
+	}
+
+	if fc.MaxStrLength > 0 && fc.MaxStrLength < fc.MinStrLength {
+		return fmt.Errorf(
+			"FinderConfig: the maximum string length (%d)"+
+				" must be >= the minimum string length (%d)",
+			fc.MaxStrLength, fc.MinStrLength)
+	}
+
+	if !(fc.MaxLenRatio == 0 || fc.MaxLenRatio >= 1) {
+		return fmt.Errorf(
+			"FinderConfig: the MaxLenRatio (%f) must be 0 or >= 1",
+			fc.MaxLenRatio)
+	}
+
+	if fc.MaxLenDiff < 0 {
+		return fmt.Errorf("FinderConfig: the MaxLenDiff (%d) must be >= 0",
+			fc.MaxLenDiff)
+	}
+
+	if fc.Normalized {
+		if err := fc.checkNormalizedThreshold(); err != nil {
+			return err
+		}
+	}
+
+	if fc.ThresholdPolicy != nil {
+		if err := fc.ThresholdPolicy.Check(); err != nil {
+			return fmt.Errorf("FinderConfig: bad ThresholdPolicy: %w", err)
+		}
+	}
+
+	if fc.TieBreaker != nil {
+		if err := fc.TieBreaker.Check(); err != nil {
+			return fmt.Errorf("FinderConfig: bad TieBreaker: %w", err)
+		}
+	}
+
+	if fc.MinMargin < 0 {
+		return fmt.Errorf("FinderConfig: the MinMargin (%f) must be >= 0",
+			fc.MinMargin)
+	}
+
 	return nil
 }
 
 // Desc returns a string describing the finder configuration
 func (fc FinderConfig) Desc() string {
 	s := fmt.Sprintf("Threshold: %7.4f", fc.Threshold)
+	s += ", ThresholdPolicy: " + fc.thresholdPolicyDesc()
 	s += fmt.Sprintf(", MinStrLength: %2d", fc.MinStrLength)
+	s += fmt.Sprintf(", MaxStrLength: %2d", fc.MaxStrLength)
+	s += fmt.Sprintf(", MaxLenRatio: %7.4f", fc.MaxLenRatio)
+	s += fmt.Sprintf(", MaxLenDiff: %2d", fc.MaxLenDiff)
 	s += fmt.Sprintf(", MapToLowerCase: %-5.5v", fc.MapToLowerCase)
 	s += fmt.Sprintf(", StripRunes: %-9q", fc.StripRunes)
+	s += fmt.Sprintf(", MinMargin: %7.4f", fc.MinMargin)
+	s += ", TieBreaker: " + fc.tieBreakerDesc()
+	s += fmt.Sprintf(", Normalized: %-5.5v", fc.Normalized)
 
 	return s
 }
+
+// checkNormalizedThreshold returns a non-nil error if the threshold (or,
+// if there is a ThresholdPolicy, the policy's maximum threshold) could be
+// greater than 1, the largest Normalized distance
+func (fc FinderConfig) checkNormalizedThreshold() error {
+	if fc.ThresholdPolicy == nil {
+		if fc.Threshold > 1 {
+			return fmt.Errorf(
+				"FinderConfig: the Threshold (%f) must be <= 1"+
+					" when distances are Normalized",
+				fc.Threshold)
+		}
+
+		return nil
+	}
+
+	maxT, ok := fc.ThresholdPolicy.MaxThreshold()
+	if !ok {
+		return fmt.Errorf(
+			"FinderConfig: the ThresholdPolicy (%s) must be bounded"+
+				" when distances are Normalized",
+			fc.ThresholdPolicy.Desc())
+	}
+
+	if maxT > 1 {
+		return fmt.Errorf(
+			"FinderConfig: the ThresholdPolicy maximum threshold (%f)"+
+				" must be <= 1 when distances are Normalized",
+			maxT)
+	}
+
+	return nil
+}
+
+// thresholdPolicyDesc returns a string describing the ThresholdPolicy
+func (fc FinderConfig) thresholdPolicyDesc() string {
+	if fc.ThresholdPolicy == nil {
+		return "none"
+	}
+
+	return fc.ThresholdPolicy.Desc()
+}
+
+// threshold returns the threshold to be used for the (prepared) target
+// string. This is given by the ThresholdPolicy if there is one and is the
+// Threshold otherwise.
+func (fc FinderConfig) threshold(s string) float64 {
+	if fc.ThresholdPolicy == nil {
+		return fc.Threshold
+	}
+
+	return fc.ThresholdPolicy.Threshold(s)
+}
+
+// lenOK returns true if the length (in runes) of a string is within the
+// minimum and maximum string lengths
+func (fc FinderConfig) lenOK(sLen int) bool {
+	if sLen < fc.MinStrLength {
+		return false
+	}
+
+	return fc.MaxStrLength == 0 || sLen <= fc.MaxStrLength
+}
+
+// lensComparable returns true if the lengths (in runes) of the target and
+// a population string are close enough, according to the MaxLenRatio and
+// the MaxLenDiff, for them to be compared
+func (fc FinderConfig) lensComparable(tLen, pLen int) bool {
+	shorter, longer := min(tLen, pLen), max(tLen, pLen)
+
+	if fc.MaxLenDiff > 0 && longer-shorter > fc.MaxLenDiff {
+		return false
+	}
+
+	if fc.MaxLenRatio > 0 && shorter != longer {
+		ratio := math.Inf(1)
+		if shorter > 0 {
+			ratio = float64(longer) / float64(shorter)
+		}
+
+		if ratio > fc.MaxLenRatio {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tieBreakerDesc returns a string describing the TieBreaker
+func (fc FinderConfig) tieBreakerDesc() string {
+	if fc.TieBreaker == nil {
+		return DefaultTieBreaker{}.Desc()
+	}
+
+	return fc.TieBreaker.Desc()
+}
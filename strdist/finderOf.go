@@ -0,0 +1,184 @@
+package strdist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Match records a record from the population, the key through which it
+// matched and the associated distance
+type Match[T any] struct {
+	Rec  T
+	Key  string
+	Dist float64
+}
+
+// String returns a string form of the Match
+func (m Match[T]) String() string {
+	return fmt.Sprintf("Rec: %v, Key: %q, Dist: %.5f", m.Rec, m.Key, m.Dist)
+}
+
+// FinderOf is a Finder for populations of arbitrary records rather than of
+// strings. Each record has one or more keys (given by a key func) and these
+// are the strings compared against the target. This avoids having to map
+// back from the matching strings to the records.
+type FinderOf[T any] struct {
+	Finder
+	keys func(T) []string
+}
+
+// NewFinderOf checks that the parameters are valid and creates a new
+// FinderOf if they are. The key func gives the string to be compared for
+// each record; it must not be nil. The FinderConfig and Algo are as for
+// NewMultiKeyFinderOf.
+func NewFinderOf[T any](fc FinderConfig, algo Algo, key func(T) string,
+) (*FinderOf[T], error) {
+	if key == nil {
+		return nil, errors.New("the key func must not be nil")
+	}
+
+	return NewMultiKeyFinderOf(fc, algo,
+		func(rec T) []string { return []string{key(rec)} })
+}
+
+// NewFinderOfOrPanic returns a new FinderOf. It will panic if there is
+// anything wrong with the parameters.
+func NewFinderOfOrPanic[T any](fc FinderConfig, algo Algo, key func(T) string,
+) *FinderOf[T] {
+	f, err := NewFinderOf(fc, algo, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// NewMultiKeyFinderOf checks that the parameters are valid and creates a new
+// FinderOf if they are. The keys func gives the strings to be compared for
+// each record (for instance a command name and its aliases); it must not
+// be nil. The FinderConfig and Algo are as for NewFinder except that the
+// Algo must not give population-relative distances (see
+// EnsembleRankFusion).
+func NewMultiKeyFinderOf[T any](fc FinderConfig, algo Algo,
+	keys func(T) []string,
+) (*FinderOf[T], error) {
+	if keys == nil {
+		return nil, errors.New("the keys func must not be nil")
+	}
+
+	if _, ok := popRanker(algo); ok {
+		return nil, fmt.Errorf(
+			"the %s Algo cannot be used by a FinderOf"+
+				" as it ranks the whole population",
+			algo.Name())
+	}
+
+	f, err := NewFinder(fc, algo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FinderOf[T]{
+		Finder: *f,
+		keys:   keys,
+	}, nil
+}
+
+// NewMultiKeyFinderOfOrPanic returns a new FinderOf. It will panic if there
+// is anything wrong with the parameters.
+func NewMultiKeyFinderOfOrPanic[T any](fc FinderConfig, algo Algo,
+	keys func(T) []string,
+) *FinderOf[T] {
+	f, err := NewMultiKeyFinderOf(fc, algo, keys)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// FindLike returns Matches for those records in the population (pop) having
+// a key which is similar to the string (s). Similarity is as for the
+// Finder.FindLike func. Where a record has more than one similar key only
+// the closest is given (the first if several are equally close) so each
+// record appears at most once. The Matches are in the same order as for
+// Finder.FindLike; records with the same key and distance are in
+// population order.
+func (f *FinderOf[T]) FindLike(s string, pop ...T) []Match[T] {
+	if len(pop) == 0 {
+		return nil
+	}
+
+	s, ok := f.prepTarget(s)
+	if !ok {
+		return nil
+	}
+
+	matches := make([]Match[T], 0, len(pop))
+
+	for _, rec := range pop {
+		if m, ok := f.bestMatch(s, rec); ok {
+			matches = append(matches, m)
+		}
+	}
+
+	sortByDist(matches, f.cmpFunc(s), Match[T].strDist)
+
+	return matches
+}
+
+// bestMatch returns the Match for the closest of the record's keys to the
+// target string (which must already have been prepared by prepTarget). It
+// returns false if none of the keys is similar to the target.
+func (f *FinderOf[T]) bestMatch(s string, rec T) (Match[T], bool) {
+	var (
+		best  Match[T]
+		found bool
+	)
+
+	for _, key := range f.keys(rec) {
+		d, ok := f.dist(s, key)
+		if !ok {
+			continue
+		}
+
+		if !found || d < best.Dist {
+			best = Match[T]{Rec: rec, Key: key, Dist: d}
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// strDist returns the StrDist for the Match
+func (m Match[T]) strDist() StrDist {
+	return StrDist{Str: m.Key, Dist: m.Dist}
+}
+
+// FindRecLike returns those records in the population (pop) which are
+// similar to the string (s). Similarity is as for the FindLike func.
+func (f *FinderOf[T]) FindRecLike(s string, pop ...T) []T {
+	return convertMatchesN(-1, f.FindLike(s, pop...))
+}
+
+// FindNRecLike returns the first n records in the population (pop) which
+// are similar to the string (s). Similarity is as for the FindLike func.
+func (f *FinderOf[T]) FindNRecLike(n int, s string, pop ...T) []T {
+	return convertMatchesN(n, f.FindLike(s, pop...))
+}
+
+// convertMatchesN returns the records from the first n Matches. If n is
+// negative the records from all the Matches are returned.
+func convertMatchesN[T any](n int, matches []Match[T]) []T {
+	if n < 0 || len(matches) < n {
+		n = len(matches)
+	}
+
+	rval := make([]T, 0, n)
+	for _, m := range matches[:n] {
+		rval = append(rval, m.Rec)
+	}
+
+	return rval
+}
@@ -0,0 +1,186 @@
+package strdist_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+// command is a record type used to test the FinderOf
+type command struct {
+	name    string
+	aliases []string
+}
+
+// cmdKeys returns the name and aliases of the command
+func cmdKeys(c command) []string {
+	return append([]string{c.name}, c.aliases...)
+}
+
+func TestNewFinderOf(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		fc       strdist.FinderConfig
+		key      func(command) string
+		multiKey bool
+		keys     func(command) []string
+	}{
+		{
+			ID:  testhelper.MkID("good"),
+			key: func(c command) string { return c.name },
+		},
+		{
+			ID:     testhelper.MkID("nil key func"),
+			ExpErr: testhelper.MkExpErr("the key func must not be nil"),
+		},
+		{
+			ID:  testhelper.MkID("bad config"),
+			fc:  strdist.FinderConfig{Threshold: -1},
+			key: func(c command) string { return c.name },
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the Threshold (-1.000000) must be >= 0"),
+		},
+		{
+			ID:       testhelper.MkID("multiple keys, good"),
+			multiKey: true,
+			keys:     cmdKeys,
+		},
+		{
+			ID:       testhelper.MkID("multiple keys, nil keys func"),
+			multiKey: true,
+			ExpErr:   testhelper.MkExpErr("the keys func must not be nil"),
+		},
+	}
+
+	for _, tc := range testCases {
+		var err error
+		if tc.multiKey {
+			_, err = strdist.NewMultiKeyFinderOf(tc.fc,
+				strdist.LevenshteinAlgo{}, tc.keys)
+		} else {
+			_, err = strdist.NewFinderOf(tc.fc,
+				strdist.LevenshteinAlgo{}, tc.key)
+		}
+
+		testhelper.CheckExpErr(t, err, tc)
+	}
+}
+
+func TestFinderOf(t *testing.T) {
+	cmds := []command{
+		{name: "status", aliases: []string{"st", "stat"}},
+		{name: "commit", aliases: []string{"ci"}},
+		{name: "checkout", aliases: []string{"co"}},
+		{name: "stash"},
+	}
+	fc := strdist.FinderConfig{
+		Threshold:    2,
+		MinStrLength: 2,
+	}
+
+	nameFinder := strdist.NewFinderOfOrPanic(fc, strdist.LevenshteinAlgo{},
+		func(c command) string { return c.name })
+	keysFinder := strdist.NewMultiKeyFinderOfOrPanic(fc,
+		strdist.LevenshteinAlgo{}, cmdKeys)
+
+	testCases := []struct {
+		testhelper.ID
+		f          *strdist.FinderOf[command]
+		s          string
+		expMatches []strdist.Match[command]
+	}{
+		{
+			ID: testhelper.MkID("single key"),
+			f:  nameFinder,
+			s:  "stats",
+			expMatches: []strdist.Match[command]{
+				{Rec: cmds[0], Key: "status", Dist: 1},
+				{Rec: cmds[3], Key: "stash", Dist: 2},
+			},
+		},
+		{
+			ID: testhelper.MkID("multiple keys, first equally close key chosen"),
+			f:  keysFinder,
+			s:  "stats",
+			expMatches: []strdist.Match[command]{
+				{Rec: cmds[0], Key: "status", Dist: 1},
+				{Rec: cmds[3], Key: "stash", Dist: 2},
+			},
+		},
+		{
+			ID: testhelper.MkID("multiple keys, alias matches"),
+			f:  keysFinder,
+			s:  "cx",
+			expMatches: []strdist.Match[command]{
+				{Rec: cmds[1], Key: "ci", Dist: 1},
+				{Rec: cmds[2], Key: "co", Dist: 1},
+				{Rec: cmds[0], Key: "st", Dist: 2},
+			},
+		},
+		{
+			ID: testhelper.MkID("target too short"),
+			f:  keysFinder,
+			s:  "c",
+		},
+		{
+			ID: testhelper.MkID("no match"),
+			f:  keysFinder,
+			s:  "xyzzy",
+		},
+	}
+
+	for _, tc := range testCases {
+		matches := tc.f.FindLike(tc.s, cmds...)
+		testhelper.DiffInt(t, tc.IDStr(), "number of matches",
+			len(matches), len(tc.expMatches))
+
+		for i, m := range matches {
+			if i >= len(tc.expMatches) {
+				break
+			}
+
+			exp := tc.expMatches[i]
+			testhelper.DiffString(t, tc.IDStr(), "record name",
+				m.Rec.name, exp.Rec.name)
+			testhelper.DiffString(t, tc.IDStr(), "Key", m.Key, exp.Key)
+			testhelper.DiffFloat(t, tc.IDStr(), "Dist", m.Dist, exp.Dist, 0)
+		}
+
+		recs := tc.f.FindNRecLike(1, tc.s, cmds...)
+		if len(tc.expMatches) == 0 {
+			testhelper.DiffInt(t, tc.IDStr(), "number of records",
+				len(recs), 0)
+		} else if testhelper.DiffInt(t, tc.IDStr(), "number of records",
+			len(recs), 1) {
+			testhelper.DiffString(t, tc.IDStr(), "first record name",
+				recs[0].name, tc.expMatches[0].Rec.name)
+		}
+	}
+}
+
+func TestFinderOfRecs(t *testing.T) {
+	type product struct {
+		ID   int
+		Name string
+	}
+
+	pop := []product{
+		{ID: 1, Name: "widget"},
+		{ID: 2, Name: "gadget"},
+		{ID: 3, Name: "widgets"},
+		{ID: 4, Name: "widget"},
+	}
+	f := strdist.NewFinderOfOrPanic(
+		strdist.FinderConfig{Threshold: 1, MinStrLength: 3},
+		strdist.LevenshteinAlgo{},
+		func(p product) string { return p.Name })
+
+	testhelper.DiffSlice(t, "products", "FindRecLike",
+		f.FindRecLike("wodget", pop...),
+		[]product{pop[0], pop[3]})
+	testhelper.DiffSlice(t, "products", "FindRecLike",
+		f.FindRecLike("widget", pop...),
+		[]product{pop[0], pop[3], pop[2]})
+}
@@ -0,0 +1,277 @@
+package strdist
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// FindLikeSeq returns an iterator over StrDists for those strings in the
+// population sequence (pop) which are similar to the string (s). Similarity
+// is as for the FindLike func. The StrDists are given in population order
+// as the population is read so the population need not be held in memory;
+// use CollectSorted or CollectBestN to get them in the usual order.
+//
+// If the Algo gives population-relative distances (see EnsembleRankFusion)
+// then the whole population must be read, and held, before any StrDists
+// can be given.
+func (f *Finder) FindLikeSeq(s string, pop iter.Seq[string],
+) iter.Seq[StrDist] {
+	return func(yield func(StrDist) bool) {
+		s, ok := f.prepTarget(s)
+		if !ok {
+			return
+		}
+
+		if pr, ok := popRanker(f.Algo); ok {
+			strs := slices.Collect(pop)
+			idxs, ranks := f.rankPop(pr, s, strs)
+
+			for i, idx := range idxs {
+				if !yield(StrDist{Str: strs[idx], Dist: ranks[i]}) {
+					return
+				}
+			}
+
+			return
+		}
+
+		for p := range pop {
+			d, ok := f.dist(s, p)
+			if !ok {
+				continue
+			}
+
+			if !yield(StrDist{Str: p, Dist: d}) {
+				return
+			}
+		}
+	}
+}
+
+// FindLikeSeq2 returns an iterator over the keys and StrDists of those
+// strings in the keyed population sequence (pop) which are similar to the
+// string (s). Similarity is as for the Finder's FindLike func. The key
+// allows the matching strings to be associated with the records from which
+// they came (for instance a row number or a database ID). The matches are
+// given in population order as the population is read; as for FindLikeSeq
+// the whole population is read first if the Algo gives population-relative
+// distances.
+func FindLikeSeq2[K any](f *Finder, s string, pop iter.Seq2[K, string],
+) iter.Seq2[K, StrDist] {
+	return func(yield func(K, StrDist) bool) {
+		s, ok := f.prepTarget(s)
+		if !ok {
+			return
+		}
+
+		if pr, ok := popRanker(f.Algo); ok {
+			var (
+				keys []K
+				strs []string
+			)
+
+			for k, p := range pop {
+				keys = append(keys, k)
+				strs = append(strs, p)
+			}
+
+			idxs, ranks := f.rankPop(pr, s, strs)
+
+			for i, idx := range idxs {
+				if !yield(keys[idx],
+					StrDist{Str: strs[idx], Dist: ranks[i]}) {
+					return
+				}
+			}
+
+			return
+		}
+
+		for k, p := range pop {
+			d, ok := f.dist(s, p)
+			if !ok {
+				continue
+			}
+
+			if !yield(k, StrDist{Str: p, Dist: d}) {
+				return
+			}
+		}
+	}
+}
+
+// FindLikeSeq returns an iterator over Matches for those records in the
+// population sequence (pop) having a key which is similar to the string
+// (s). Similarity is as for the FindLike func. The Matches are given in
+// population order as the population is read.
+func (f *FinderOf[T]) FindLikeSeq(s string, pop iter.Seq[T],
+) iter.Seq[Match[T]] {
+	return func(yield func(Match[T]) bool) {
+		s, ok := f.prepTarget(s)
+		if !ok {
+			return
+		}
+
+		for rec := range pop {
+			m, ok := f.bestMatch(s, rec)
+			if !ok {
+				continue
+			}
+
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+// CollectSorted collects the StrDists from the sequence (typically from
+// FindLikeSeq with the same target string) and returns them in the same
+// order as FindLike.
+func (f *Finder) CollectSorted(s string, seq iter.Seq[StrDist]) []StrDist {
+	return collectSorted(f.CmpFunc(s), seq, func(sd StrDist) StrDist {
+		return sd
+	})
+}
+
+// CollectBestN collects the StrDists from the sequence (typically from
+// FindLikeSeq with the same target string) and returns the first n in the
+// same order as FindLike. At most n StrDists are held at any time so this
+// can be used with arbitrarily long sequences.
+func (f *Finder) CollectBestN(n int, s string, seq iter.Seq[StrDist],
+) []StrDist {
+	return collectBestN(n, f.CmpFunc(s), seq, func(sd StrDist) StrDist {
+		return sd
+	})
+}
+
+// CollectSorted collects the Matches from the sequence (typically from
+// FindLikeSeq with the same target string) and returns them in the same
+// order as FindLike.
+func (f *FinderOf[T]) CollectSorted(s string, seq iter.Seq[Match[T]],
+) []Match[T] {
+	return collectSorted(f.CmpFunc(s), seq, Match[T].strDist)
+}
+
+// CollectBestN collects the Matches from the sequence (typically from
+// FindLikeSeq with the same target string) and returns the first n in the
+// same order as FindLike. At most n Matches are held at any time so this
+// can be used with arbitrarily long sequences.
+func (f *FinderOf[T]) CollectBestN(n int, s string, seq iter.Seq[Match[T]],
+) []Match[T] {
+	return collectBestN(n, f.CmpFunc(s), seq, Match[T].strDist)
+}
+
+// collectSorted collects the values from the sequence and sorts them into
+// the order given by the cmp func.
+func collectSorted[E any](cmp func(sd1, sd2 StrDist) int, seq iter.Seq[E],
+	toStrDist func(E) StrDist,
+) []E {
+	vals := slices.Collect(seq)
+	sortByDist(vals, cmp, toStrDist)
+
+	return vals
+}
+
+// collectBestN collects the first n values from the sequence in the order
+// given by the cmp func; equal values are in sequence order. It keeps the
+// best values seen so far in a heap with the worst of them at the top so
+// that it can be cheaply replaced.
+func collectBestN[E any](n int, cmp func(sd1, sd2 StrDist) int,
+	seq iter.Seq[E], toStrDist func(E) StrDist,
+) []E {
+	if n <= 0 {
+		return nil
+	}
+
+	h := &worstFirstHeap[E]{
+		cmp:       cmp,
+		toStrDist: toStrDist,
+	}
+
+	seqNum := 0
+
+	for v := range seq {
+		e := heapEntry[E]{val: v, seqNum: seqNum}
+		seqNum++
+
+		switch {
+		case h.Len() < n:
+			heap.Push(h, e)
+		case h.better(e, h.entries[0]):
+			h.entries[0] = e
+			heap.Fix(h, 0)
+		}
+	}
+
+	slices.SortFunc(h.entries, func(e1, e2 heapEntry[E]) int {
+		switch {
+		case h.better(e1, e2):
+			return -1
+		case h.better(e2, e1):
+			return 1
+		}
+
+		return 0
+	})
+
+	vals := make([]E, 0, h.Len())
+	for _, e := range h.entries {
+		vals = append(vals, e.val)
+	}
+
+	return vals
+}
+
+// heapEntry records a value and its position in the sequence
+type heapEntry[E any] struct {
+	val    E
+	seqNum int
+}
+
+// worstFirstHeap is a heap (see container/heap) of entries with the worst
+// entry, according to the cmp func and then the sequence number, at the top
+type worstFirstHeap[E any] struct {
+	entries   []heapEntry[E]
+	cmp       func(sd1, sd2 StrDist) int
+	toStrDist func(E) StrDist
+}
+
+// better returns true if e1 is better than e2
+func (h *worstFirstHeap[E]) better(e1, e2 heapEntry[E]) bool {
+	if c := h.cmp(h.toStrDist(e1.val), h.toStrDist(e2.val)); c != 0 {
+		return c < 0
+	}
+
+	return e1.seqNum < e2.seqNum
+}
+
+// Len returns the number of entries in the heap
+func (h *worstFirstHeap[E]) Len() int { return len(h.entries) }
+
+// Less reports whether the i'th entry is worse than the j'th
+func (h *worstFirstHeap[E]) Less(i, j int) bool {
+	return h.better(h.entries[j], h.entries[i])
+}
+
+// Swap swaps the i'th and j'th entries
+func (h *worstFirstHeap[E]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+}
+
+// Push adds the entry to the heap. It should only be called through
+// heap.Push.
+func (h *worstFirstHeap[E]) Push(e any) {
+	h.entries = append(h.entries, e.(heapEntry[E]))
+}
+
+// Pop removes the last entry from the heap. It should only be called
+// through heap.Pop.
+func (h *worstFirstHeap[E]) Pop() any {
+	last := len(h.entries) - 1
+	e := h.entries[last]
+	h.entries = h.entries[:last]
+
+	return e
+}
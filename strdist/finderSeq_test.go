@@ -0,0 +1,129 @@
+package strdist_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestFindLikeSeq(t *testing.T) {
+	pop := []string{
+		"hello", "world", "help", "hell", "yellow", "jello",
+		"shell", "hallo", "hello", "he",
+	}
+	f := strdist.DefaultFinders[strdist.AlgoNameLevenshtein]
+	const target = "hello"
+
+	// the unsorted matches should be in population order
+	var expUnsorted []strdist.StrDist
+
+	for _, p := range pop {
+		if len(p) < f.MinStrLength {
+			continue
+		}
+
+		d := float64(strdist.LevenshteinDistance(target, p))
+		if d <= f.Threshold {
+			expUnsorted = append(expUnsorted, strdist.StrDist{Str: p, Dist: d})
+		}
+	}
+
+	seq := f.FindLikeSeq(target, slices.Values(pop))
+	testhelper.DiffSlice(t, "FindLikeSeq", "unsorted",
+		slices.Collect(seq), expUnsorted)
+
+	expSorted := f.FindLike(target, pop...)
+	testhelper.DiffSlice(t, "FindLikeSeq", "CollectSorted",
+		f.CollectSorted(target, seq), expSorted)
+
+	for _, n := range []int{0, 1, 3, len(expSorted), len(expSorted) + 2} {
+		exp := expSorted[:min(n, len(expSorted))]
+		if n == 0 {
+			exp = nil
+		}
+
+		testhelper.DiffSlice(t, "FindLikeSeq",
+			fmt.Sprintf("CollectBestN(%d)", n),
+			f.CollectBestN(n, target, seq), exp)
+	}
+
+	// the iteration should stop early if asked
+	count := 0
+	for range seq {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	testhelper.DiffInt(t, "FindLikeSeq", "early stop count", count, 2)
+
+	// a target shorter than the minimum length should give no matches
+	testhelper.DiffInt(t, "FindLikeSeq", "short target match count",
+		len(slices.Collect(f.FindLikeSeq("he", slices.Values(pop)))), 0)
+}
+
+func TestFindLikeSeq2(t *testing.T) {
+	pop := []string{"hello", "world", "help", "hallo"}
+	f := strdist.DefaultFinders[strdist.AlgoNameLevenshtein]
+
+	type keyedDist struct {
+		idx int
+		sd  strdist.StrDist
+	}
+
+	var got []keyedDist
+	for idx, sd := range strdist.FindLikeSeq2(f, "hello", slices.All(pop)) {
+		got = append(got, keyedDist{idx: idx, sd: sd})
+	}
+
+	testhelper.DiffSlice(t, "FindLikeSeq2", "keyed matches", got,
+		[]keyedDist{
+			{idx: 0, sd: strdist.StrDist{Str: "hello", Dist: 0}},
+			{idx: 1, sd: strdist.StrDist{Str: "world", Dist: 4}},
+			{idx: 2, sd: strdist.StrDist{Str: "help", Dist: 2}},
+			{idx: 3, sd: strdist.StrDist{Str: "hallo", Dist: 1}},
+		})
+}
+
+func TestFinderOfSeq(t *testing.T) {
+	type product struct {
+		ID   int
+		Name string
+	}
+
+	pop := []product{
+		{ID: 1, Name: "widget"},
+		{ID: 2, Name: "gadget"},
+		{ID: 3, Name: "widgets"},
+		{ID: 4, Name: "widget"},
+		{ID: 5, Name: "wodget"},
+	}
+	f := strdist.NewFinderOfOrPanic(
+		strdist.FinderConfig{Threshold: 1, MinStrLength: 3},
+		strdist.LevenshteinAlgo{},
+		func(p product) string { return p.Name })
+
+	seq := f.FindLikeSeq("widget", slices.Values(pop))
+
+	ids := func(matches []strdist.Match[product]) []int {
+		rval := []int{}
+		for _, m := range matches {
+			rval = append(rval, m.Rec.ID)
+		}
+
+		return rval
+	}
+
+	testhelper.DiffSlice(t, "FinderOf", "unsorted",
+		ids(slices.Collect(seq)), []int{1, 3, 4, 5})
+	testhelper.DiffSlice(t, "FinderOf", "CollectSorted",
+		ids(f.CollectSorted("widget", seq)), []int{1, 4, 5, 3})
+	testhelper.DiffSlice(t, "FinderOf", "CollectBestN(2)",
+		ids(f.CollectBestN(2, "widget", seq)), []int{1, 4})
+	testhelper.DiffSlice(t, "FinderOf", "CollectBestN(3)",
+		ids(f.CollectBestN(3, "widget", seq)), []int{1, 4, 5})
+}
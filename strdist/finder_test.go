@@ -1,6 +1,7 @@
 package strdist_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/nickwells/strdist.mod/v2/strdist"
@@ -35,6 +36,46 @@ func TestCommonFinder(t *testing.T) {
 			ExpErr: testhelper.MkExpErr(
 				"FinderConfig: the Threshold (-1.000000) must be >= 0"),
 		},
+		{
+			ID: testhelper.MkID("bad MinMargin"),
+			fc: strdist.FinderConfig{Threshold: 1.2, MinMargin: -0.5},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the MinMargin (-0.500000) must be >= 0"),
+		},
+		{
+			ID: testhelper.MkID("bad MaxStrLength"),
+			fc: strdist.FinderConfig{MaxStrLength: -1},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the maximum string length (-1) must be >= 0"),
+		},
+		{
+			ID: testhelper.MkID("MaxStrLength < MinStrLength"),
+			fc: strdist.FinderConfig{MinStrLength: 4, MaxStrLength: 3},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the maximum string length (3)" +
+					" must be >= the minimum string length (4)"),
+		},
+		{
+			ID: testhelper.MkID("bad MaxLenRatio"),
+			fc: strdist.FinderConfig{MaxLenRatio: 0.5},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the MaxLenRatio (0.500000) must be 0 or >= 1"),
+		},
+		{
+			ID: testhelper.MkID("bad MaxLenDiff"),
+			fc: strdist.FinderConfig{MaxLenDiff: -2},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the MaxLenDiff (-2) must be >= 0"),
+		},
+		{
+			ID: testhelper.MkID("bad ThresholdPolicy"),
+			fc: strdist.FinderConfig{
+				ThresholdPolicy: strdist.AbsoluteThreshold(-1),
+			},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: bad ThresholdPolicy:" +
+					" the threshold (-1.000000) must be finite and >= 0"),
+		},
 	}
 
 	var a TestAlgo
@@ -49,3 +90,186 @@ func TestCommonFinder(t *testing.T) {
 		}
 	}
 }
+
+func TestFindBest(t *testing.T) {
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: 3, MinStrLength: 2},
+		strdist.LevenshteinAlgo{})
+	fMargin := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: 3, MinStrLength: 2, MinMargin: 2},
+		strdist.LevenshteinAlgo{})
+
+	testCases := []struct {
+		testhelper.ID
+		f         *strdist.Finder
+		s         string
+		pop       []string
+		expBest   strdist.StrDist
+		expUnique bool
+		expGap    float64
+	}{
+		{
+			ID:  testhelper.MkID("no match"),
+			f:   f,
+			s:   "commit",
+			pop: []string{"status", "push"},
+		},
+		{
+			ID:        testhelper.MkID("single match"),
+			f:         f,
+			s:         "comit",
+			pop:       []string{"commit", "status", "push"},
+			expBest:   strdist.StrDist{Str: "commit", Dist: 1},
+			expUnique: true,
+			expGap:    math.Inf(1),
+		},
+		{
+			ID:        testhelper.MkID("duplicates are not runners-up"),
+			f:         f,
+			s:         "comit",
+			pop:       []string{"commit", "commit"},
+			expBest:   strdist.StrDist{Str: "commit", Dist: 1},
+			expUnique: true,
+			expGap:    math.Inf(1),
+		},
+		{
+			ID:        testhelper.MkID("clear winner"),
+			f:         f,
+			s:         "pul",
+			pop:       []string{"pull", "push", "status"},
+			expBest:   strdist.StrDist{Str: "pull", Dist: 1},
+			expUnique: true,
+			expGap:    1,
+		},
+		{
+			ID:        testhelper.MkID("tied"),
+			f:         f,
+			s:         "pus",
+			pop:       []string{"pull", "push", "plus"},
+			expBest:   strdist.StrDist{Str: "plus", Dist: 1},
+			expUnique: false,
+			expGap:    0,
+		},
+		{
+			ID:        testhelper.MkID("gap smaller than the margin"),
+			f:         fMargin,
+			s:         "pul",
+			pop:       []string{"pull", "push", "status"},
+			expBest:   strdist.StrDist{Str: "pull", Dist: 1},
+			expUnique: false,
+			expGap:    1,
+		},
+		{
+			ID:        testhelper.MkID("gap equal to the margin"),
+			f:         fMargin,
+			s:         "pul",
+			pop:       []string{"pull", "pint"},
+			expBest:   strdist.StrDist{Str: "pull", Dist: 1},
+			expUnique: true,
+			expGap:    2,
+		},
+	}
+
+	for _, tc := range testCases {
+		best, unique, gap := tc.f.FindBest(tc.s, tc.pop...)
+		testhelper.DiffString(t, tc.IDStr(), "best string",
+			best.Str, tc.expBest.Str)
+		testhelper.DiffFloat(t, tc.IDStr(), "best distance",
+			best.Dist, tc.expBest.Dist, 0)
+		testhelper.DiffBool(t, tc.IDStr(), "unique", unique, tc.expUnique)
+		testhelper.DiffFloat(t, tc.IDStr(), "gap", gap, tc.expGap, 0)
+	}
+}
+
+func TestFinderLengthFilters(t *testing.T) {
+	algo := strdist.LevenshteinAlgo{}
+
+	testCases := []struct {
+		testhelper.ID
+		fc  strdist.FinderConfig
+		s   string
+		pop []string
+		exp []string
+	}{
+		{
+			ID:  testhelper.MkID("MinStrLength counts runes - target"),
+			fc:  strdist.FinderConfig{Threshold: 1, MinStrLength: 3},
+			s:   "日本",
+			pop: []string{"日本", "日本語"},
+		},
+		{
+			ID:  testhelper.MkID("MinStrLength counts runes - population"),
+			fc:  strdist.FinderConfig{Threshold: 1, MinStrLength: 3},
+			s:   "日本語",
+			pop: []string{"日本", "日本語", "日本人"},
+			exp: []string{"日本語", "日本人"},
+		},
+		{
+			ID: testhelper.MkID("MaxStrLength"),
+			fc: strdist.FinderConfig{
+				Threshold:    3,
+				MinStrLength: 1,
+				MaxStrLength: 5,
+			},
+			s:   "abcd",
+			pop: []string{"abc", "abcde", "abcdef", "abcdéf"},
+			exp: []string{"abc", "abcde"},
+		},
+		{
+			ID: testhelper.MkID("MaxStrLength - long target"),
+			fc: strdist.FinderConfig{
+				Threshold:    3,
+				MinStrLength: 1,
+				MaxStrLength: 5,
+			},
+			s:   "abcdef",
+			pop: []string{"abcde", "abcdef"},
+		},
+		{
+			ID:  testhelper.MkID("MaxLenDiff"),
+			fc:  strdist.FinderConfig{Threshold: 3, MaxLenDiff: 1},
+			s:   "abcd",
+			pop: []string{"ab", "abc", "abcd", "abcde", "abcdef", "wxyz"},
+			exp: []string{"abcd", "abc", "abcde"},
+		},
+		{
+			ID:  testhelper.MkID("MaxLenRatio"),
+			fc:  strdist.FinderConfig{Threshold: 3, MaxLenRatio: 1.5},
+			s:   "abcd",
+			pop: []string{"", "ab", "abc", "abcd", "abcdef", "abcdefg"},
+			exp: []string{"abcd", "abc", "abcdef"},
+		},
+	}
+
+	for _, tc := range testCases {
+		f := strdist.NewFinderOrPanic(tc.fc, algo)
+		finderChecker(t, tc.IDStr(), "length filters", tc.s, tc.pop, f, tc.exp)
+	}
+}
+
+func TestDefaultFinders(t *testing.T) {
+	for name := range strdist.DefaultThresholds {
+		for _, n := range []string{name, "case-blind " + name} {
+			f, ok := strdist.DefaultFinders[n]
+			if !ok {
+				t.Errorf("there is no default Finder for %q", n)
+				continue
+			}
+
+			testhelper.DiffString(t, n, "Algo name", f.Algo.Name(), name)
+		}
+	}
+}
+
+// nanAlgo is an Algo which always gives a NaN distance
+type nanAlgo struct{}
+
+func (nanAlgo) Dist(_, _ string) float64 { return math.NaN() }
+func (nanAlgo) Name() string             { return "NaN" }
+func (nanAlgo) Desc() string             { return "" }
+
+func TestFinderNaNDist(t *testing.T) {
+	f := strdist.NewFinderOrPanic(strdist.FinderConfig{Threshold: 1}, nanAlgo{})
+	finderChecker(t, "NaN distance", "FindLike",
+		"abc", []string{"abc", "abd"}, f, []string{})
+}
@@ -0,0 +1,236 @@
+package strdist
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// DfltEditProb is the default probability of each edit for a
+// PerEditErrorModel
+const DfltEditProb = 0.01
+
+// ErrorModel gives the probability that a string was entered when another
+// string, at the given distance from it, was intended. This is used by a
+// FrequencyFinder to weigh the distance of a candidate against its
+// frequency.
+type ErrorModel interface {
+	// LogProb returns the natural logarithm of the probability of a string
+	// being entered in place of one at the given distance
+	LogProb(dist float64) float64
+	// Check returns a non-nil error if the ErrorModel is invalid
+	Check() error
+	// Desc returns a string describing the ErrorModel
+	Desc() string
+}
+
+// PerEditErrorModel is an ErrorModel where each edit happens independently
+// with the same probability. The distance is taken to be the number of
+// edits so this is suitable for use with the Levenshtein family of
+// algorithms; the probability of a string at distance d is EditProb^d.
+type PerEditErrorModel struct {
+	EditProb float64
+}
+
+// LogProb returns the logarithm of the probability of the given number of
+// edits
+func (em PerEditErrorModel) LogProb(dist float64) float64 {
+	return dist * math.Log(em.EditProb)
+}
+
+// Check returns a non-nil error if the EditProb is not a valid probability
+func (em PerEditErrorModel) Check() error {
+	if !(em.EditProb > 0 && em.EditProb <= 1) {
+		return fmt.Errorf("the EditProb (%f) must be > 0 and <= 1",
+			em.EditProb)
+	}
+
+	return nil
+}
+
+// Desc returns a string describing the ErrorModel
+func (em PerEditErrorModel) Desc() string {
+	return fmt.Sprintf("per edit: EditProb: %g", em.EditProb)
+}
+
+// ScoredStrDist records a string, its distance from the target and the
+// combined score given by a FrequencyFinder. The higher the score the
+// better the match.
+type ScoredStrDist struct {
+	StrDist
+	Score float64
+}
+
+// String returns a string form of the ScoredStrDist
+func (ssd ScoredStrDist) String() string {
+	return fmt.Sprintf("%s, Score: %.5f", ssd.StrDist, ssd.Score)
+}
+
+// FrequencyFinder finds the strings in a vocabulary which are similar to a
+// target and ranks them by combining their distance from the target with
+// how frequently they are used. This is the approach taken by Peter
+// Norvig's spelling corrector: the best candidate is the one which
+// maximises P(candidate) * P(target | candidate). The first term is given
+// by the frequencies and the second by the ErrorModel so a slightly more
+// distant but far more common word can be preferred.
+//
+// The candidates are found by the Finder (so its threshold and other
+// constraints apply) and the score is the natural logarithm of the product
+// of the probabilities. The frequencies are smoothed by adding the
+// smoothing value to each of them (Laplace smoothing) so that words with a
+// zero frequency can still be found.
+type FrequencyFinder struct {
+	finder    *Finder
+	freqs     map[string]float64
+	vocab     []string
+	errModel  ErrorModel
+	smoothing float64
+	logTotal  float64
+}
+
+// NewFrequencyFinder checks that the parameters are valid and returns a new
+// FrequencyFinder if they are. The Finder must not be nil and its Algo must
+// not give population-relative distances (see EnsembleRankFusion) as these
+// cannot be weighed by the ErrorModel. The vocabulary is the set of keys of
+// the frequency map which must not be empty; the frequencies and the
+// smoothing value must be finite and >= 0 and, unless the smoothing value
+// is greater than zero, some frequency must be non-zero. If the ErrorModel
+// is nil then a PerEditErrorModel with an EditProb of DfltEditProb is used.
+func NewFrequencyFinder(f *Finder, freqs map[string]float64,
+	em ErrorModel, smoothing float64,
+) (*FrequencyFinder, error) {
+	if f == nil {
+		return nil, errors.New("the Finder must not be nil")
+	}
+
+	if _, ok := popRanker(f.Algo); ok {
+		return nil, fmt.Errorf(
+			"the %s Algo cannot be used by a FrequencyFinder"+
+				" as it ranks the whole population",
+			f.Algo.Name())
+	}
+
+	if len(freqs) == 0 {
+		return nil, errors.New("the frequency map must not be empty")
+	}
+
+	if em == nil {
+		em = PerEditErrorModel{EditProb: DfltEditProb}
+	}
+
+	if err := em.Check(); err != nil {
+		return nil, fmt.Errorf("bad ErrorModel: %w", err)
+	}
+
+	if !(smoothing >= 0) || math.IsInf(smoothing, 1) {
+		return nil, fmt.Errorf("the smoothing (%f) must be finite and >= 0",
+			smoothing)
+	}
+
+	vocab := slices.Sorted(maps.Keys(freqs))
+
+	var total float64
+
+	for _, w := range vocab {
+		freq := freqs[w]
+		if !(freq >= 0) || math.IsInf(freq, 1) {
+			return nil, fmt.Errorf(
+				"the frequency (%f) of %q must be finite and >= 0", freq, w)
+		}
+
+		total += freq + smoothing
+	}
+
+	if total == 0 {
+		return nil, errors.New(
+			"the frequencies must not all be zero if there is no smoothing")
+	}
+
+	return &FrequencyFinder{
+		finder:    f,
+		freqs:     maps.Clone(freqs),
+		vocab:     vocab,
+		errModel:  em,
+		smoothing: smoothing,
+		logTotal:  math.Log(total),
+	}, nil
+}
+
+// NewFrequencyFinderOrPanic returns a new FrequencyFinder. It will panic if
+// the FrequencyFinder cannot be created without errors.
+func NewFrequencyFinderOrPanic(f *Finder, freqs map[string]float64,
+	em ErrorModel, smoothing float64,
+) *FrequencyFinder {
+	ff, err := NewFrequencyFinder(f, freqs, em, smoothing)
+	if err != nil {
+		panic(err)
+	}
+
+	return ff
+}
+
+// LogProb returns the natural logarithm of the (smoothed) probability of
+// the word. This is -Inf if the word has a zero probability.
+func (ff *FrequencyFinder) LogProb(word string) float64 {
+	return math.Log(ff.freqs[word]+ff.smoothing) - ff.logTotal
+}
+
+// FindLike returns ScoredStrDists for those strings in the vocabulary which
+// the Finder finds to be similar to the string (s). They are in descending
+// order of score; those with the same score are in the order given by the
+// Finder.
+func (ff *FrequencyFinder) FindLike(s string) []ScoredStrDist {
+	dists := ff.finder.FindLike(s, ff.vocab...)
+
+	scored := make([]ScoredStrDist, 0, len(dists))
+	for _, sd := range dists {
+		scored = append(scored, ScoredStrDist{
+			StrDist: sd,
+			Score:   ff.LogProb(sd.Str) + ff.errModel.LogProb(sd.Dist),
+		})
+	}
+
+	slices.SortStableFunc(scored, func(ssd1, ssd2 ScoredStrDist) int {
+		return cmp.Compare(ssd2.Score, ssd1.Score)
+	})
+
+	return scored
+}
+
+// FindStrLike returns those strings in the vocabulary which are similar to
+// the string (s). They are in the order given by the FindLike func.
+func (ff *FrequencyFinder) FindStrLike(s string) []string {
+	return ff.FindNStrLike(-1, s)
+}
+
+// FindNStrLike returns the first n strings in the vocabulary which are
+// similar to the string (s). They are in the order given by the FindLike
+// func. If n is negative all the similar strings are returned.
+func (ff *FrequencyFinder) FindNStrLike(n int, s string) []string {
+	scored := ff.FindLike(s)
+	if n < 0 || len(scored) < n {
+		n = len(scored)
+	}
+
+	rval := make([]string, 0, n)
+	for _, ssd := range scored[:n] {
+		rval = append(rval, ssd.Str)
+	}
+
+	return rval
+}
+
+// Correct returns the best of the strings in the vocabulary which are
+// similar to the string (s), as given by the FindLike func. It returns
+// false if there are no similar strings.
+func (ff *FrequencyFinder) Correct(s string) (string, bool) {
+	scored := ff.FindLike(s)
+	if len(scored) == 0 {
+		return "", false
+	}
+
+	return scored[0].Str, true
+}
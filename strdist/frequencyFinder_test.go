@@ -0,0 +1,212 @@
+package strdist_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewFrequencyFinder(t *testing.T) {
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: 2, MinStrLength: 2},
+		strdist.LevenshteinAlgo{})
+
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		f         *strdist.Finder
+		freqs     map[string]float64
+		em        strdist.ErrorModel
+		smoothing float64
+	}{
+		{
+			ID:    testhelper.MkID("good"),
+			f:     f,
+			freqs: map[string]float64{"the": 100, "then": 0},
+		},
+		{
+			ID: testhelper.MkID("rank fusion Finder"),
+			f: strdist.NewFinderOrPanic(
+				strdist.FinderConfig{Threshold: 2},
+				strdist.NewEnsembleAlgoOrPanic(strdist.EnsembleRankFusion,
+					strdist.EnsembleComponent{
+						Algo:   strdist.LevenshteinAlgo{},
+						Weight: 1,
+					})),
+			freqs: map[string]float64{"the": 100},
+			ExpErr: testhelper.MkExpErr(
+				"the ensemble Algo cannot be used by a FrequencyFinder"),
+		},
+		{
+			ID:     testhelper.MkID("nil Finder"),
+			freqs:  map[string]float64{"the": 100},
+			ExpErr: testhelper.MkExpErr("the Finder must not be nil"),
+		},
+		{
+			ID:     testhelper.MkID("no frequencies"),
+			f:      f,
+			ExpErr: testhelper.MkExpErr("the frequency map must not be empty"),
+		},
+		{
+			ID:    testhelper.MkID("bad ErrorModel"),
+			f:     f,
+			freqs: map[string]float64{"the": 100},
+			em:    strdist.PerEditErrorModel{EditProb: 1.5},
+			ExpErr: testhelper.MkExpErr(
+				"bad ErrorModel: the EditProb (1.500000) must be > 0 and <= 1"),
+		},
+		{
+			ID:        testhelper.MkID("bad smoothing"),
+			f:         f,
+			freqs:     map[string]float64{"the": 100},
+			smoothing: math.NaN(),
+			ExpErr: testhelper.MkExpErr(
+				"the smoothing (NaN) must be finite and >= 0"),
+		},
+		{
+			ID:    testhelper.MkID("bad frequency"),
+			f:     f,
+			freqs: map[string]float64{"the": 100, "then": -1},
+			ExpErr: testhelper.MkExpErr(
+				`the frequency (-1.000000) of "then" must be finite and >= 0`),
+		},
+		{
+			ID:    testhelper.MkID("all zero"),
+			f:     f,
+			freqs: map[string]float64{"the": 0, "then": 0},
+			ExpErr: testhelper.MkExpErr(
+				"the frequencies must not all be zero" +
+					" if there is no smoothing"),
+		},
+		{
+			ID:        testhelper.MkID("all zero, smoothed"),
+			f:         f,
+			freqs:     map[string]float64{"the": 0, "then": 0},
+			smoothing: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		ff, err := strdist.NewFrequencyFinder(tc.f, tc.freqs, tc.em,
+			tc.smoothing)
+		if testhelper.CheckExpErr(t, err, tc) && err == nil && ff == nil {
+			t.Log(tc.IDStr())
+			t.Errorf("\t: a nil pointer was returned but no error\n")
+		}
+	}
+}
+
+func TestFrequencyFinder(t *testing.T) {
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: 2, MinStrLength: 2},
+		strdist.LevenshteinAlgo{})
+	freqs := map[string]float64{
+		"the":   10000,
+		"thee":  10,
+		"then":  500,
+		"thy":   5,
+		"tea":   200,
+		"theta": 0,
+	}
+
+	testCases := []struct {
+		testhelper.ID
+		em        strdist.ErrorModel
+		smoothing float64
+		s         string
+		exp       []string
+		expOK     bool
+	}{
+		{
+			ID: testhelper.MkID("common word wins"),
+			s:  "thea",
+			exp: []string{
+				"the", "then", "tea", "thee", "thy", "theta",
+			},
+			expOK: true,
+		},
+		{
+			ID: testhelper.MkID("exact match wins"),
+			s:  "then",
+			exp: []string{
+				"then", "the", "thee", "tea", "thy", "theta",
+			},
+			expOK: true,
+		},
+		{
+			ID: testhelper.MkID("high edit probability"),
+			em: strdist.PerEditErrorModel{EditProb: 0.5},
+			s:  "then",
+			exp: []string{
+				"the", "then", "tea", "thee", "thy", "theta",
+			},
+			expOK: true,
+		},
+		{
+			ID:        testhelper.MkID("smoothed"),
+			smoothing: 1,
+			s:         "thet",
+			exp: []string{
+				"the", "then", "thee", "tea", "theta", "thy",
+			},
+			expOK: true,
+		},
+		{
+			ID: testhelper.MkID("no match"),
+			s:  "xyzzy",
+		},
+	}
+
+	for _, tc := range testCases {
+		ff := strdist.NewFrequencyFinderOrPanic(f, freqs, tc.em, tc.smoothing)
+		testhelper.DiffStringSlice(t, tc.IDStr(), "found",
+			ff.FindStrLike(tc.s), tc.exp)
+
+		best, ok := ff.Correct(tc.s)
+		if testhelper.DiffBool(t, tc.IDStr(), "Correct ok", ok, tc.expOK) ||
+			!ok {
+			continue
+		}
+
+		testhelper.DiffString(t, tc.IDStr(), "Correct", best, tc.exp[0])
+	}
+}
+
+func TestFrequencyFinderScores(t *testing.T) {
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: 2, MinStrLength: 2},
+		strdist.LevenshteinAlgo{})
+	ff := strdist.NewFrequencyFinderOrPanic(f,
+		map[string]float64{"the": 3, "then": 1},
+		strdist.PerEditErrorModel{EditProb: 0.1}, 0)
+
+	testhelper.DiffFloat(t, "LogProb", "the", ff.LogProb("the"),
+		math.Log(0.75), 1e-9)
+	testhelper.DiffFloat(t, "LogProb", "unknown", ff.LogProb("xxx"),
+		math.Inf(-1), 0)
+
+	scored := ff.FindLike("thenn")
+	exp := []strdist.ScoredStrDist{
+		{
+			StrDist: strdist.StrDist{Str: "then", Dist: 1},
+			Score:   math.Log(0.25) + math.Log(0.1),
+		},
+		{
+			StrDist: strdist.StrDist{Str: "the", Dist: 2},
+			Score:   math.Log(0.75) + 2*math.Log(0.1),
+		},
+	}
+
+	if testhelper.DiffInt(t, "FindLike", "len", len(scored), len(exp)) {
+		return
+	}
+
+	for i, ssd := range scored {
+		testhelper.DiffString(t, "FindLike", "Str", ssd.Str, exp[i].Str)
+		testhelper.DiffFloat(t, "FindLike", "Dist", ssd.Dist, exp[i].Dist, 0)
+		testhelper.DiffFloat(t, "FindLike", "Score",
+			ssd.Score, exp[i].Score, 1e-9)
+	}
+}
@@ -0,0 +1,148 @@
+package strdist
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// FuzzyMatch describes an approximate occurrence of a pattern in a text
+type FuzzyMatch struct {
+	// Start and End give the rune offsets (not byte offsets) of the
+	// matching substring in the text so it is []rune(text)[Start:End]
+	Start, End int
+	// Dist is the Levenshtein distance between the pattern and the matching
+	// substring
+	Dist int
+}
+
+// FuzzySearcher finds the places in a text where a pattern occurs with at
+// most a given number of edits (insertions, deletions or substitutions of
+// runes). The distances are as given by LevenshteinDistance.
+type FuzzySearcher struct {
+	pattern []rune
+	maxDist int
+}
+
+// NewFuzzySearcher returns a new FuzzySearcher for the pattern. The pattern
+// must not be empty and the maximum distance must be >= 0 and less than the
+// number of runes in the pattern (otherwise every position in the text
+// would match).
+func NewFuzzySearcher(pattern string, maxDist int) (*FuzzySearcher, error) {
+	if pattern == "" {
+		return nil, errors.New("the pattern must not be empty")
+	}
+
+	if maxDist < 0 {
+		return nil, fmt.Errorf("the maximum distance (%d) must be >= 0",
+			maxDist)
+	}
+
+	if patLen := utf8.RuneCountInString(pattern); maxDist >= patLen {
+		return nil, fmt.Errorf("the maximum distance (%d)"+
+			" must be less than the pattern length (%d)",
+			maxDist, patLen)
+	}
+
+	return &FuzzySearcher{
+		pattern: []rune(pattern),
+		maxDist: maxDist,
+	}, nil
+}
+
+// NewFuzzySearcherOrPanic returns a new FuzzySearcher. It will panic if the
+// FuzzySearcher cannot be created without errors.
+func NewFuzzySearcherOrPanic(pattern string, maxDist int) *FuzzySearcher {
+	fs, err := NewFuzzySearcher(pattern, maxDist)
+	if err != nil {
+		panic(err)
+	}
+
+	return fs
+}
+
+// FuzzySearch returns the places in the text where the pattern occurs with
+// at most maxDist edits. See FuzzySearcher.Search for details. An error is
+// returned if the pattern or the maximum distance are invalid.
+func FuzzySearch(pattern, text string, maxDist int) ([]FuzzyMatch, error) {
+	fs, err := NewFuzzySearcher(pattern, maxDist)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs.Search(text), nil
+}
+
+// Search returns the places in the text where the pattern occurs with at
+// most the maximum number of edits, in the order they appear in the text.
+//
+// It uses Sellers' algorithm: this calculates the Levenshtein distance
+// between the pattern and the best substring of the text ending at each
+// position in the text by allowing the match to start anywhere at no cost.
+// An approximate match will typically give several overlapping matches
+// within the maximum distance (for instance the exact match of "abc" in
+// "xabcx" is accompanied by the matches of "xabc" and "abcx" with one
+// edit). Only the best of a set of overlapping matches is returned; this is
+// the one with the smallest distance and then the shortest and earliest.
+// Matches which are adjacent but do not overlap are all returned.
+func (fs FuzzySearcher) Search(text string) []FuzzyMatch {
+	m := len(fs.pattern)
+
+	// the rows hold the distances between the prefixes of the pattern and
+	// the best substrings of the text ending at the current position; the
+	// aux values are the rune offsets at which those substrings start.
+	// Where there is a choice the later start (the shorter match) is kept.
+	prev, curr := newLevRow(m), newLevRow(m)
+
+	for i := range prev.dist {
+		prev.dist[i] = i
+	}
+
+	var (
+		matches []FuzzyMatch
+		best    FuzzyMatch
+		found   bool
+	)
+
+	end := 0
+
+	for _, tRune := range text {
+		end++
+
+		curr.dist[0], curr.aux[0] = 0, end
+
+		levRowStep(tRune, fs.pattern, prev, curr, 0)
+
+		if d := curr.dist[m]; d <= fs.maxDist {
+			fm := FuzzyMatch{Start: curr.aux[m], End: end, Dist: d}
+
+			switch {
+			case !found:
+				best, found = fm, true
+			case fm.Start >= best.End:
+				matches = append(matches, best)
+				best = fm
+			case fm.betterThan(best):
+				best = fm
+			}
+		}
+
+		prev, curr = curr, prev
+	}
+
+	if found {
+		matches = append(matches, best)
+	}
+
+	return matches
+}
+
+// betterThan returns true if fm has a smaller distance than other or, if
+// the distances are the same, is shorter
+func (fm FuzzyMatch) betterThan(other FuzzyMatch) bool {
+	if fm.Dist != other.Dist {
+		return fm.Dist < other.Dist
+	}
+
+	return fm.End-fm.Start < other.End-other.Start
+}
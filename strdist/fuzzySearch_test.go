@@ -0,0 +1,169 @@
+package strdist_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewFuzzySearcher(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		pattern string
+		maxDist int
+	}{
+		{
+			ID:      testhelper.MkID("good"),
+			pattern: "abc",
+			maxDist: 2,
+		},
+		{
+			ID:      testhelper.MkID("empty pattern"),
+			pattern: "",
+			ExpErr:  testhelper.MkExpErr("the pattern must not be empty"),
+		},
+		{
+			ID:      testhelper.MkID("negative maxDist"),
+			pattern: "abc",
+			maxDist: -1,
+			ExpErr: testhelper.MkExpErr(
+				"the maximum distance (-1) must be >= 0"),
+		},
+		{
+			ID:      testhelper.MkID("maxDist too big"),
+			pattern: "a§c",
+			maxDist: 3,
+			ExpErr: testhelper.MkExpErr(
+				"the maximum distance (3)" +
+					" must be less than the pattern length (3)"),
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := strdist.NewFuzzySearcher(tc.pattern, tc.maxDist)
+		testhelper.CheckExpErr(t, err, tc)
+	}
+}
+
+func TestFuzzySearch(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		pattern    string
+		text       string
+		maxDist    int
+		expMatches []strdist.FuzzyMatch
+	}{
+		{
+			ID:      testhelper.MkID("no match"),
+			pattern: "abc",
+			text:    "xyz xyz",
+			maxDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("empty text"),
+			pattern: "abc",
+			text:    "",
+			maxDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("exact match, surrounding matches ignored"),
+			pattern: "abc",
+			text:    "xxabcxx",
+			maxDist: 1,
+			expMatches: []strdist.FuzzyMatch{
+				{Start: 2, End: 5, Dist: 0},
+			},
+		},
+		{
+			ID:      testhelper.MkID("exact match only"),
+			pattern: "hello",
+			text:    "say helo to hallo world, hello",
+			maxDist: 0,
+			expMatches: []strdist.FuzzyMatch{
+				{Start: 25, End: 30, Dist: 0},
+			},
+		},
+		{
+			ID:      testhelper.MkID("several matches"),
+			pattern: "hello",
+			text:    "say helo to hallo world, hello",
+			maxDist: 1,
+			expMatches: []strdist.FuzzyMatch{
+				{Start: 4, End: 8, Dist: 1},
+				{Start: 12, End: 17, Dist: 1},
+				{Start: 25, End: 30, Dist: 0},
+			},
+		},
+		{
+			ID:      testhelper.MkID("multi-byte runes, rune offsets"),
+			pattern: "café",
+			text:    "un cafe au lait, un café",
+			maxDist: 1,
+			expMatches: []strdist.FuzzyMatch{
+				{Start: 3, End: 6, Dist: 1},
+				{Start: 20, End: 24, Dist: 0},
+			},
+		},
+		{
+			ID:      testhelper.MkID("adjacent matches"),
+			pattern: "abc",
+			text:    "abcabc",
+			maxDist: 1,
+			expMatches: []strdist.FuzzyMatch{
+				{Start: 0, End: 3, Dist: 0},
+				{Start: 3, End: 6, Dist: 0},
+			},
+		},
+		{
+			ID:      testhelper.MkID("adjacent matches, no edits"),
+			pattern: "ab",
+			text:    "xababx",
+			maxDist: 0,
+			expMatches: []strdist.FuzzyMatch{
+				{Start: 1, End: 3, Dist: 0},
+				{Start: 3, End: 5, Dist: 0},
+			},
+		},
+		{
+			ID:      testhelper.MkID("adjacent approximate and exact matches"),
+			pattern: "abcd",
+			text:    "abxdabcd",
+			maxDist: 1,
+			expMatches: []strdist.FuzzyMatch{
+				{Start: 0, End: 4, Dist: 1},
+				{Start: 4, End: 8, Dist: 0},
+			},
+		},
+		{
+			ID:      testhelper.MkID("overlapping matches"),
+			pattern: "aba",
+			text:    "ababa",
+			maxDist: 0,
+			expMatches: []strdist.FuzzyMatch{
+				{Start: 0, End: 3, Dist: 0},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		matches, err := strdist.FuzzySearch(tc.pattern, tc.text, tc.maxDist)
+		if err != nil {
+			t.Log(tc.IDStr())
+			t.Errorf("\t: unexpected error: %s\n", err)
+
+			continue
+		}
+
+		testhelper.DiffSlice(t, tc.IDStr(), "matches", matches, tc.expMatches)
+
+		text := []rune(tc.text)
+		for _, m := range matches {
+			testhelper.DiffInt(t, tc.IDStr(), "match distance",
+				m.Dist,
+				strdist.LevenshteinDistance(tc.pattern,
+					string(text[m.Start:m.End])))
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package strdist
+
+import (
+	"math"
+	"slices"
+)
+
+// These values record how the best score in a cell of the gotohTable was
+// reached. The low bits give the source of the score in the H table and
+// the flags record whether the E and F scores extend an existing gap.
+const (
+	gotohStop uint8 = iota
+	gotohDiag
+	gotohFromE
+	gotohFromF
+
+	gotohHMask   uint8 = 0x3
+	gotohEExtend uint8 = 0x4
+	gotohFExtend uint8 = 0x8
+)
+
+// gotohTable holds the tables of scores for the score-based alignment
+// algorithms using Gotoh's method for affine gaps. For a local alignment
+// (Smith-Waterman) no score can fall below zero and the alignment can start
+// and end anywhere. For a global alignment (Needleman-Wunsch) the alignment
+// runs from the start to the end of both strings.
+//
+// Each table is stored as a flat slice with the entry for a[:i], b[:j] at
+// i*cols+j.
+type gotohTable struct {
+	a, b  []rune
+	sc    AlignScores
+	cols  int
+	local bool
+
+	// h holds the best score of an alignment ending at the cell, e the best
+	// score ending with a gap in a (runes inserted from b) and f the best
+	// score ending with a gap in b (runes deleted from a)
+	h, e, f []float64
+	from    []uint8
+
+	// bestScore is the score of the best alignment, which ends at the cell
+	// for a[:bestI], b[:bestJ]
+	bestScore    float64
+	bestI, bestJ int
+}
+
+// newGotohTable creates and populates the gotohTable for a and b
+func newGotohTable(a, b []rune, sc AlignScores, local bool) *gotohTable {
+	cols := len(b) + 1
+	size := (len(a) + 1) * cols
+
+	t := &gotohTable{
+		a:     a,
+		b:     b,
+		sc:    sc,
+		cols:  cols,
+		local: local,
+		h:     make([]float64, size),
+		e:     make([]float64, size),
+		f:     make([]float64, size),
+		from:  make([]uint8, size),
+	}
+
+	t.initEdges()
+
+	for i, aRune := range a {
+		row, prevRow := (i+1)*cols, i*cols
+
+		for j, bRune := range b {
+			cell := row + j + 1
+			up, left, diag := prevRow+j+1, row+j, prevRow+j
+
+			var from uint8
+
+			t.e[cell] = t.h[left] - sc.GapOpen
+			if ext := t.e[left] - sc.GapExtend; ext >= t.e[cell] {
+				t.e[cell] = ext
+				from |= gotohEExtend
+			}
+
+			t.f[cell] = t.h[up] - sc.GapOpen
+			if ext := t.f[up] - sc.GapExtend; ext >= t.f[cell] {
+				t.f[cell] = ext
+				from |= gotohFExtend
+			}
+
+			best, hFrom := t.h[diag]+sc.score(aRune, bRune), gotohDiag
+			if t.f[cell] > best {
+				best, hFrom = t.f[cell], gotohFromF
+			}
+
+			if t.e[cell] > best {
+				best, hFrom = t.e[cell], gotohFromE
+			}
+
+			if local && best <= 0 {
+				best, hFrom = 0, gotohStop
+			}
+
+			t.h[cell] = best
+			t.from[cell] = from | hFrom
+
+			if local && best > t.bestScore {
+				t.bestScore, t.bestI, t.bestJ = best, i+1, j+1
+			}
+		}
+	}
+
+	if !local {
+		t.bestI, t.bestJ = len(a), len(b)
+		t.bestScore = t.h[len(a)*cols+len(b)]
+	}
+
+	return t
+}
+
+// gotohScore returns the score of the best alignment of a and b. This is
+// the same as the bestScore of the gotohTable but only two rows of the
+// tables are kept so it needs far less memory. It should be used when the
+// alignment itself is not needed.
+func gotohScore(a, b []rune, sc AlignScores, local bool) float64 {
+	negInf := math.Inf(-1)
+	cols := len(b) + 1
+
+	hPrev, hCur := make([]float64, cols), make([]float64, cols)
+	fPrev, fCur := make([]float64, cols), make([]float64, cols)
+
+	for j := range cols {
+		fPrev[j] = negInf
+
+		if !local && j > 0 {
+			hPrev[j] = -sc.GapOpen - float64(j-1)*sc.GapExtend
+		}
+	}
+
+	bestScore := 0.0
+
+	for i, aRune := range a {
+		hCur[0], fCur[0] = 0, negInf
+		if !local {
+			hCur[0] = -sc.GapOpen - float64(i)*sc.GapExtend
+			fCur[0] = hCur[0]
+		}
+
+		e := negInf
+
+		for j, bRune := range b {
+			e = max(hCur[j]-sc.GapOpen, e-sc.GapExtend)
+			fCur[j+1] = max(hPrev[j+1]-sc.GapOpen, fPrev[j+1]-sc.GapExtend)
+
+			best := max(hPrev[j]+sc.score(aRune, bRune), fCur[j+1], e)
+			if local {
+				best = max(best, 0)
+				bestScore = max(bestScore, best)
+			}
+
+			hCur[j+1] = best
+		}
+
+		hPrev, hCur = hCur, hPrev
+		fPrev, fCur = fCur, fPrev
+	}
+
+	if !local {
+		bestScore = hPrev[len(b)]
+	}
+
+	return bestScore
+}
+
+// initEdges sets the first row and column of the tables. For a local
+// alignment the H scores are zero and for a global alignment they are the
+// penalty for a gap from the start of the string.
+func (t *gotohTable) initEdges() {
+	negInf := math.Inf(-1)
+
+	for j := range t.cols {
+		t.e[j], t.f[j] = negInf, negInf
+
+		if t.local || j == 0 {
+			continue
+		}
+
+		t.h[j] = -t.sc.GapOpen - float64(j-1)*t.sc.GapExtend
+		t.e[j] = t.h[j]
+		t.from[j] = gotohFromE
+
+		if j > 1 {
+			t.from[j] |= gotohEExtend
+		}
+	}
+
+	for i := 1; i <= len(t.a); i++ {
+		row := i * t.cols
+		t.e[row], t.f[row] = negInf, negInf
+
+		if t.local {
+			continue
+		}
+
+		t.h[row] = -t.sc.GapOpen - float64(i-1)*t.sc.GapExtend
+		t.f[row] = t.h[row]
+		t.from[row] = gotohFromF
+
+		if i > 1 {
+			t.from[row] |= gotohFExtend
+		}
+	}
+}
+
+// localDist returns the normalised local alignment distance for the best
+// score. This is 1 minus the score divided by the score of the shorter
+// string aligned with itself. If that self-score is not positive (as for
+// the zero value of the AlignScores) the score cannot be normalised and the
+// distance is 0 if the strings are identical and 1 otherwise.
+func localDist(a, b []rune, sc AlignScores, score float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		if len(a) == len(b) {
+			return 0
+		}
+
+		return 1
+	}
+
+	selfScore := min(sc.selfScore(a), sc.selfScore(b))
+	if selfScore <= 0 {
+		return unscaledDist(a, b)
+	}
+
+	return max(0, 1-score/selfScore)
+}
+
+// globalDist returns the normalised global alignment distance for the best
+// score. This is 1 minus the score divided by the score of the longer
+// string aligned with itself, limited to lie between 0 and 1. If that
+// self-score is not positive (as for the zero value of the AlignScores) the
+// score cannot be normalised and the distance is 0 if the strings are
+// identical and 1 otherwise.
+func globalDist(a, b []rune, sc AlignScores, score float64) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+
+	selfScore := max(sc.selfScore(a), sc.selfScore(b))
+	if selfScore <= 0 {
+		return unscaledDist(a, b)
+	}
+
+	return min(1, max(0, 1-score/selfScore))
+}
+
+// unscaledDist returns 0 if the two rune slices are identical and 1
+// otherwise
+func unscaledDist(a, b []rune) float64 {
+	if slices.Equal(a, b) {
+		return 0
+	}
+
+	return 1
+}
+
+// traceback returns the edits of the best alignment and the offsets in a
+// and b at which the alignment starts.
+func (t gotohTable) traceback() (edits []Edit, aStart, bStart int) {
+	// the state gives the table being traced back through; gotohDiag is
+	// used for the H table and gotohFromE and gotohFromF for the E and F
+	// tables
+	state := gotohDiag
+
+	i, j := t.bestI, t.bestJ
+	for i > 0 || j > 0 {
+		from := t.from[i*t.cols+j]
+
+		if state == gotohDiag {
+			state = from & gotohHMask
+			if state == gotohStop {
+				break
+			}
+		}
+
+		switch state {
+		case gotohDiag:
+			edits = append(edits, mkSubEdit(t.a, t.b, i-1, j-1, 0, 0))
+			i--
+			j--
+		case gotohFromE:
+			edits = append(edits, mkInsEdit(t.b, j-1, i, 0))
+			j--
+
+			if from&gotohEExtend == 0 {
+				state = gotohDiag
+			}
+		case gotohFromF:
+			edits = append(edits, mkDelEdit(t.a, i-1, 0, j))
+			i--
+
+			if from&gotohFExtend == 0 {
+				state = gotohDiag
+			}
+		}
+	}
+
+	slices.Reverse(edits)
+
+	return edits, i, j
+}
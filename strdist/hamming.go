@@ -1,40 +1,147 @@
 package strdist
 
 import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
 	"unicode/utf8"
 )
 
 // HammingAlgo encapsulates the details needed to provide the Hamming distance.
-type HammingAlgo struct{}
+type HammingAlgo struct {
+	// Strict is set to indicate that strings of different lengths cannot be
+	// compared. The distance between them is +Inf. If it is not set then
+	// the distance is increased by the difference in the lengths.
+	Strict bool
+	// Bits is set to indicate that the bits of the (UTF-8 encoded) strings
+	// should be compared rather than the runes. The distance is the number
+	// of differing bits and any extra bytes in the longer string count as
+	// eight differing bits.
+	Bits bool
+}
 
 // Name returns the algorithm name
 func (HammingAlgo) Name() string {
 	return AlgoNameHamming
 }
 
-// Desc returns a string describing the algorithm configuration
-func (HammingAlgo) Desc() string {
-	return ""
+// Desc returns a string describing the algorithm configuration. This is
+// empty for the default configuration.
+func (a HammingAlgo) Desc() string {
+	if !a.Strict && !a.Bits {
+		return ""
+	}
+
+	return fmt.Sprintf("Strict: %-5.5v Bits: %-5.5v", a.Strict, a.Bits)
 }
 
-// Dist returns the Hamming distance of the two strings. if the two strings
-// are of different length then the Hamming distance is increased by the
-// difference in lengths. Note that it compares runes rather than characters
-// or chars
-func (HammingAlgo) Dist(s1, s2 string) float64 {
-	d := utf8.RuneCountInString(s2) - utf8.RuneCountInString(s1)
-	if d < 0 {
-		d *= -1
-		s1, s2 = s2, s1 // s1 is longer than s2 so swap
+// Dist returns the Hamming distance of the two strings. If the two strings
+// are of different length then, unless the algorithm is Strict, the Hamming
+// distance is increased by the difference in lengths. Note that it compares
+// runes rather than characters or chars unless the algorithm compares Bits
+func (a HammingAlgo) Dist(s1, s2 string) float64 {
+	if a.Bits {
+		b1, b2 := []byte(s1), []byte(s2)
+		if a.Strict && len(b1) != len(b2) {
+			return math.Inf(1)
+		}
+
+		n := min(len(b1), len(b2))
+		lenDiff := max(len(b1), len(b2)) - n
+
+		return float64(hammingBits(b1[:n], b2[:n]) + lenDiff*8)
 	}
 
 	r1, r2 := []rune(s1), []rune(s2)
-	for i, s1r := range r1 {
-		s2r := r2[i]
-		if s1r != s2r {
+	if a.Strict && len(r1) != len(r2) {
+		return math.Inf(1)
+	}
+
+	n := min(len(r1), len(r2))
+	lenDiff := max(len(r1), len(r2)) - n
+
+	return float64(hammingRunes(r1[:n], r2[:n]) + lenDiff)
+}
+
+// NormalizedDist for a HammingAlgo gives the Hamming distance divided by
+// the length of the longer string (in bits if the algorithm compares Bits,
+// otherwise in runes). For a Strict algorithm strings of different lengths
+// have a distance of 1.
+func (a HammingAlgo) NormalizedDist(s1, s2 string) float64 {
+	length := max(utf8.RuneCountInString(s1), utf8.RuneCountInString(s2))
+	if a.Bits {
+		length = max(len(s1), len(s2)) * 8
+	}
+
+	return normalizeByLen(a.Dist(s1, s2), length)
+}
+
+// HammingDistance returns the Hamming distance between strings a and b,
+// this is the number of runes that differ. The strings must have the same
+// number of runes, if not a non-nil error is returned.
+func HammingDistance(a, b string) (int, error) {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) != len(rb) {
+		return 0, fmt.Errorf(
+			"the strings must be the same length (%d runes != %d runes)",
+			len(ra), len(rb))
+	}
+
+	return hammingRunes(ra, rb), nil
+}
+
+// HammingBytes returns the Hamming distance between the bits of a and b,
+// this is the number of bits that differ. The slices must be the same
+// length, if not a non-nil error is returned.
+func HammingBytes(a, b []byte) (int, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf(
+			"the slices must be the same length (%d bytes != %d bytes)",
+			len(a), len(b))
+	}
+
+	return hammingBits(a, b), nil
+}
+
+// HammingUint64 returns the Hamming distance between the bits of a and b,
+// this is the number of bits that differ. This can be used to compare
+// fingerprints (such as SimHash values) of strings.
+func HammingUint64(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// hammingRunes returns the number of runes that differ between a and b. The
+// slices must be the same length.
+func hammingRunes(a, b []rune) int {
+	d := 0
+
+	for i, r := range a {
+		if r != b[i] {
 			d++
 		}
 	}
 
-	return float64(d)
+	return d
+}
+
+// hammingBits returns the number of bits that differ between a and b. The
+// slices must be the same length. The bytes are compared 64 bits at a time.
+func hammingBits(a, b []byte) int {
+	const wordLen = 8
+
+	d := 0
+
+	for len(a) >= wordLen {
+		d += HammingUint64(
+			binary.LittleEndian.Uint64(a),
+			binary.LittleEndian.Uint64(b))
+		a, b = a[wordLen:], b[wordLen:]
+	}
+
+	for i, v := range a {
+		d += bits.OnesCount8(v ^ b[i])
+	}
+
+	return d
 }
@@ -1,7 +1,9 @@
 package strdist_test
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/nickwells/strdist.mod/v2/strdist"
@@ -175,3 +177,211 @@ func TestHammingFinder(t *testing.T) {
 			flatCaseFinder, tc.expNStringsFlatCase)
 	}
 }
+
+func TestHammingAlgoModes(t *testing.T) {
+	inf := math.Inf(1)
+
+	testCases := []struct {
+		testhelper.ID
+		algo    strdist.HammingAlgo
+		a, b    string
+		expDist float64
+		expDesc string
+	}{
+		{
+			ID:      testhelper.MkID("padded, unequal lengths"),
+			algo:    strdist.HammingAlgo{},
+			a:       "abcd",
+			b:       "xbc",
+			expDist: 2,
+			expDesc: "",
+		},
+		{
+			ID:      testhelper.MkID("strict, equal lengths"),
+			algo:    strdist.HammingAlgo{Strict: true},
+			a:       "a§a",
+			b:       "a¶b",
+			expDist: 2,
+			expDesc: "Strict: true  Bits: false",
+		},
+		{
+			ID:      testhelper.MkID("strict, unequal lengths"),
+			algo:    strdist.HammingAlgo{Strict: true},
+			a:       "abcd",
+			b:       "abc",
+			expDist: inf,
+			expDesc: "Strict: true  Bits: false",
+		},
+		{
+			ID:      testhelper.MkID("bits"),
+			algo:    strdist.HammingAlgo{Bits: true},
+			a:       "a",
+			b:       "b",
+			expDist: 2,
+			expDesc: "Strict: false Bits: true ",
+		},
+		{
+			ID:      testhelper.MkID("bits, multi-byte runes"),
+			algo:    strdist.HammingAlgo{Bits: true},
+			a:       "a§a",
+			b:       "a¶a",
+			expDist: 2,
+			expDesc: "Strict: false Bits: true ",
+		},
+		{
+			ID:      testhelper.MkID("bits, long strings"),
+			algo:    strdist.HammingAlgo{Bits: true},
+			a:       "abcdefghij",
+			b:       "abcdefghik",
+			expDist: 1,
+			expDesc: "Strict: false Bits: true ",
+		},
+		{
+			ID:      testhelper.MkID("bits, padded"),
+			algo:    strdist.HammingAlgo{Bits: true},
+			a:       "ab",
+			b:       "a",
+			expDist: 8,
+			expDesc: "Strict: false Bits: true ",
+		},
+		{
+			ID:      testhelper.MkID("bits, strict"),
+			algo:    strdist.HammingAlgo{Strict: true, Bits: true},
+			a:       "aé",
+			b:       "ae",
+			expDist: inf,
+			expDesc: "Strict: true  Bits: true ",
+		},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("Dist(%q, %q)", tc.a, tc.b),
+			tc.algo.Dist(tc.a, tc.b), tc.expDist, 0)
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("Dist(%q, %q)", tc.b, tc.a),
+			tc.algo.Dist(tc.b, tc.a), tc.expDist, 0)
+		testhelper.DiffString(t, tc.IDStr(), "Desc",
+			tc.algo.Desc(), tc.expDesc)
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		a, b    string
+		expDist int
+	}{
+		{
+			ID:      testhelper.MkID("equal lengths"),
+			a:       "karolin",
+			b:       "kathrin",
+			expDist: 3,
+		},
+		{
+			ID:      testhelper.MkID("multi-byte runes"),
+			a:       "a§a",
+			b:       "a¶a",
+			expDist: 1,
+		},
+		{
+			ID: testhelper.MkID("unequal lengths"),
+			a:  "abc",
+			b:  "ab",
+			ExpErr: testhelper.MkExpErr(
+				"the strings must be the same length" +
+					" (3 runes != 2 runes)"),
+		},
+	}
+
+	for _, tc := range testCases {
+		d, err := strdist.HammingDistance(tc.a, tc.b)
+		if testhelper.CheckExpErr(t, err, tc) &&
+			err == nil {
+			testhelper.DiffInt(t, tc.IDStr(), "distance", d, tc.expDist)
+		}
+	}
+}
+
+func TestHammingBytes(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		a, b    []byte
+		expDist int
+	}{
+		{
+			ID:      testhelper.MkID("empty"),
+			a:       []byte{},
+			b:       []byte{},
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("short"),
+			a:       []byte{0x0f, 0x00},
+			b:       []byte{0x00, 0x01},
+			expDist: 5,
+		},
+		{
+			ID:      testhelper.MkID("two words"),
+			a:       bytes.Repeat([]byte{0x00}, 16),
+			b:       bytes.Repeat([]byte{0xff}, 16),
+			expDist: 128,
+		},
+		{
+			ID:      testhelper.MkID("word and a byte"),
+			a:       bytes.Repeat([]byte{0x00}, 9),
+			b:       bytes.Repeat([]byte{0x81}, 9),
+			expDist: 18,
+		},
+		{
+			ID: testhelper.MkID("unequal lengths"),
+			a:  []byte{0x00},
+			b:  []byte{},
+			ExpErr: testhelper.MkExpErr(
+				"the slices must be the same length" +
+					" (1 bytes != 0 bytes)"),
+		},
+	}
+
+	for _, tc := range testCases {
+		d, err := strdist.HammingBytes(tc.a, tc.b)
+		if testhelper.CheckExpErr(t, err, tc) &&
+			err == nil {
+			testhelper.DiffInt(t, tc.IDStr(), "distance", d, tc.expDist)
+		}
+	}
+}
+
+func TestHammingUint64(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b    uint64
+		expDist int
+	}{
+		{
+			ID:      testhelper.MkID("same"),
+			a:       0xdeadbeef,
+			b:       0xdeadbeef,
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("all differ"),
+			a:       0,
+			b:       math.MaxUint64,
+			expDist: 64,
+		},
+		{
+			ID:      testhelper.MkID("some differ"),
+			a:       0b1010,
+			b:       0b0101,
+			expDist: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffInt(t, tc.IDStr(), "distance",
+			strdist.HammingUint64(tc.a, tc.b), tc.expDist)
+	}
+}
@@ -65,6 +65,11 @@ func (a *JaccardAlgo) Dist(s1, s2 string) float64 {
 	return 1.0 - JaccardIndex(ngs1, ngs2)
 }
 
+// NormalizedDist for a JaccardAlgo is the same as Dist
+func (a *JaccardAlgo) NormalizedDist(s1, s2 string) float64 {
+	return clamp01(a.Dist(s1, s2))
+}
+
 // JaccardIndex returns the Jaccard index of the two n-gram sets
 func JaccardIndex(ngs1, ngs2 NGramSet) float64 {
 	if len(ngs1) == 0 && len(ngs2) == 0 {
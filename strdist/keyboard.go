@@ -0,0 +1,278 @@
+package strdist
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// KeyboardRow describes a row of keys on a keyboard
+type KeyboardRow struct {
+	// Offset is the distance, in key widths, from the left of the keyboard
+	// to the left of the first key in the row
+	Offset float64
+	// Keys gives the runes produced by the keys in the row, from left to
+	// right, when the shift key is not pressed
+	Keys string
+	// Shifted gives the runes produced by the keys in the row when the
+	// shift key is pressed. It may be empty but otherwise it must have the
+	// same number of runes as Keys
+	Shifted string
+}
+
+// keyPos records the position of a key on the keyboard
+type keyPos struct {
+	row int
+	x   float64
+}
+
+// KeyboardLayout records the positions of the keys on a keyboard
+type KeyboardLayout struct {
+	name string
+	keys map[rune]keyPos
+}
+
+// NewKeyboardLayout returns a new KeyboardLayout with the keys in the given
+// rows. The rows are given from the top of the keyboard to the bottom and
+// are taken to be one key width apart. It is an error for a rune to appear
+// more than once in the layout.
+func NewKeyboardLayout(name string, rows ...KeyboardRow) (
+	*KeyboardLayout, error,
+) {
+	kl := &KeyboardLayout{
+		name: name,
+		keys: map[rune]keyPos{},
+	}
+
+	for i, row := range rows {
+		if row.Shifted != "" &&
+			utf8.RuneCountInString(row.Shifted) !=
+				utf8.RuneCountInString(row.Keys) {
+			return nil, fmt.Errorf(
+				"keyboard layout %q, row %d:"+
+					" the shifted keys (%q) must match the keys (%q)",
+				name, i, row.Shifted, row.Keys)
+		}
+
+		for _, keys := range []string{row.Keys, row.Shifted} {
+			col := 0
+			for _, r := range keys {
+				if _, ok := kl.keys[r]; ok {
+					return nil, fmt.Errorf(
+						"keyboard layout %q, row %d: duplicate key: %q",
+						name, i, r)
+				}
+
+				kl.keys[r] = keyPos{
+					row: i,
+					x:   row.Offset + float64(col),
+				}
+				col++
+			}
+		}
+	}
+
+	return kl, nil
+}
+
+// NewKeyboardLayoutOrPanic returns a new KeyboardLayout. It will panic if
+// the layout cannot be created without errors.
+func NewKeyboardLayoutOrPanic(name string, rows ...KeyboardRow,
+) *KeyboardLayout {
+	kl, err := NewKeyboardLayout(name, rows...)
+	if err != nil {
+		panic(err)
+	}
+
+	return kl
+}
+
+// Name returns the name of the keyboard layout
+func (kl KeyboardLayout) Name() string {
+	return kl.name
+}
+
+// KeyDistance returns the distance between the centres of the keys
+// producing the two runes, measured in key widths, and true. If either rune
+// is not on the keyboard then it returns 0 and false. Runes on the same key
+// (with and without the shift key pressed) are zero distance apart.
+func (kl KeyboardLayout) KeyDistance(r1, r2 rune) (float64, bool) {
+	kp1, ok := kl.keys[r1]
+	if !ok {
+		return 0, false
+	}
+
+	kp2, ok := kl.keys[r2]
+	if !ok {
+		return 0, false
+	}
+
+	return math.Hypot(kp1.x-kp2.x, float64(kp1.row-kp2.row)), true
+}
+
+// keyAdjacencyLimit is the distance between two keys below which they are
+// taken to be adjacent. This includes the keys either side in the same row
+// and the (typically two) nearest keys in the rows above and below.
+const keyAdjacencyLimit = 1.5
+
+// These are the standard keyboard layouts. Only the keys producing
+// printable runes are given.
+var (
+	KeyboardQWERTY = NewKeyboardLayoutOrPanic("QWERTY",
+		KeyboardRow{Offset: 0, Keys: "`1234567890-=", Shifted: "~!@#$%^&*()_+"},
+		KeyboardRow{Offset: 1.5, Keys: `qwertyuiop[]\`, Shifted: "QWERTYUIOP{}|"},
+		KeyboardRow{Offset: 1.75, Keys: "asdfghjkl;'", Shifted: `ASDFGHJKL:"`},
+		KeyboardRow{Offset: 2.25, Keys: "zxcvbnm,./", Shifted: "ZXCVBNM<>?"},
+	)
+	KeyboardAZERTY = NewKeyboardLayoutOrPanic("AZERTY",
+		KeyboardRow{Offset: 1, Keys: `&é"'(-è_çà)=`, Shifted: "1234567890°+"},
+		KeyboardRow{Offset: 1.5, Keys: "azertyuiop^$", Shifted: "AZERTYUIOP¨£"},
+		KeyboardRow{Offset: 1.75, Keys: "qsdfghjklmù*", Shifted: "QSDFGHJKLM%µ"},
+		KeyboardRow{Offset: 1.25, Keys: "<wxcvbn,;:!", Shifted: ">WXCVBN?./§"},
+	)
+	KeyboardQWERTZ = NewKeyboardLayoutOrPanic("QWERTZ",
+		KeyboardRow{Offset: 0, Keys: "^1234567890ß´", Shifted: "°!\"§$%&/()=?`"},
+		KeyboardRow{Offset: 1.5, Keys: "qwertzuiopü+", Shifted: "QWERTZUIOPÜ*"},
+		KeyboardRow{Offset: 1.75, Keys: "asdfghjklöä#", Shifted: "ASDFGHJKLÖÄ'"},
+		KeyboardRow{Offset: 1.25, Keys: "<yxcvbnm,.-", Shifted: ">YXCVBNM;:_"},
+	)
+	KeyboardDvorak = NewKeyboardLayoutOrPanic("Dvorak",
+		KeyboardRow{Offset: 0, Keys: "`1234567890[]", Shifted: "~!@#$%^&*(){}"},
+		KeyboardRow{Offset: 1.5, Keys: `',.pyfgcrl/=\`, Shifted: `"<>PYFGCRL?+|`},
+		KeyboardRow{Offset: 1.75, Keys: "aoeuidhtns-", Shifted: "AOEUIDHTNS_"},
+		KeyboardRow{Offset: 2.25, Keys: ";qjkxbmwvz", Shifted: ":QJKXBMWVZ"},
+	)
+)
+
+// These constants are the defaults for the KeyboardCostModel
+const (
+	DfltKeyboardAdjacentCost = 0.5
+	DfltKeyboardShiftCost    = 0.5
+	DfltKeyboardTransCost    = 0.4
+)
+
+// KeyboardCostModel is a CostModel which makes the substitution of runes on
+// nearby keys cheaper than other substitutions. This reflects the
+// likelihood of a typing error where the wrong key has been pressed (a
+// "fat-finger" error). The OpCosts give the costs of the other operations
+// and of substitutions between other runes.
+type KeyboardCostModel struct {
+	OpCosts
+	// Layout gives the positions of the keys on the keyboard
+	Layout *KeyboardLayout
+	// AdjacentCost is the cost of substituting a rune with one on an
+	// adjacent key
+	AdjacentCost float64
+	// ShiftCost is the cost of substituting a rune with the other rune on
+	// the same key (as if the shift key was or was not pressed in error)
+	ShiftCost float64
+}
+
+// NewKeyboardCostModel returns a new KeyboardCostModel for the keyboard
+// layout. The OpCosts are the DamerauLevenshteinCosts except that the
+// commonly made error of transposing two runes costs
+// DfltKeyboardTransCost. This is less than the cost of substituting a rune
+// on an adjacent key so that a transposition is preferred to a single
+// fat-finger error. The costs of substituting runes on nearby keys take
+// their default values.
+func NewKeyboardCostModel(kl *KeyboardLayout) *KeyboardCostModel {
+	oc := DamerauLevenshteinCosts
+	oc.Trans = DfltKeyboardTransCost
+
+	return &KeyboardCostModel{
+		OpCosts:      oc,
+		Layout:       kl,
+		AdjacentCost: DfltKeyboardAdjacentCost,
+		ShiftCost:    DfltKeyboardShiftCost,
+	}
+}
+
+// SubCost returns the cost of substituting rune from with rune to. This
+// will be the ShiftCost or the AdjacentCost if the runes are on the same
+// key or on adjacent keys and the OpCosts substitution cost otherwise.
+func (kcm KeyboardCostModel) SubCost(from, to rune) float64 {
+	d, ok := kcm.Layout.KeyDistance(from, to)
+
+	switch {
+	case !ok:
+		return kcm.Sub
+	case d == 0:
+		return kcm.ShiftCost
+	case d < keyAdjacencyLimit:
+		return kcm.AdjacentCost
+	}
+
+	return kcm.Sub
+}
+
+// Check returns a non-nil error if the cost model is invalid
+func (kcm KeyboardCostModel) Check() error {
+	if kcm.Layout == nil {
+		return errors.New("the keyboard Layout must not be nil")
+	}
+
+	if err := checkCost("adjacent key substitution",
+		kcm.AdjacentCost); err != nil {
+		return err
+	}
+
+	if err := checkCost("shift key substitution",
+		kcm.ShiftCost); err != nil {
+		return err
+	}
+
+	return kcm.OpCosts.Check()
+}
+
+// Desc returns a string describing the costs
+func (kcm KeyboardCostModel) Desc() string {
+	s := kcm.OpCosts.Desc()
+	if kcm.Layout != nil {
+		s += " Layout: " + kcm.Layout.Name()
+	}
+
+	s += fmt.Sprintf(" Adjacent: %.3g", kcm.AdjacentCost)
+	s += fmt.Sprintf(" Shift: %.3g", kcm.ShiftCost)
+
+	return s
+}
+
+// KeyboardAlgo encapsulates the details needed to provide the keyboard
+// distance. This is the weighted Levenshtein distance using a
+// KeyboardCostModel and so typing errors, where a nearby key has been
+// pressed or two runes have been transposed, give smaller distances than
+// other edits.
+type KeyboardAlgo struct {
+	*WeightedLevenshteinAlgo
+}
+
+// NewKeyboardAlgo returns a new KeyboardAlgo with the cost model set
+func NewKeyboardAlgo(kcm *KeyboardCostModel) (*KeyboardAlgo, error) {
+	if kcm == nil {
+		return nil, errors.New("the KeyboardCostModel must not be nil")
+	}
+
+	wla, err := NewWeightedLevenshteinAlgo(kcm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyboardAlgo{WeightedLevenshteinAlgo: wla}, nil
+}
+
+// NewKeyboardAlgoOrPanic returns a new KeyboardAlgo. It will panic if the
+// algo cannot be created without errors.
+func NewKeyboardAlgoOrPanic(kcm *KeyboardCostModel) *KeyboardAlgo {
+	a, err := NewKeyboardAlgo(kcm)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// Name returns the algorithm name
+func (KeyboardAlgo) Name() string {
+	return AlgoNameKeyboard
+}
@@ -0,0 +1,231 @@
+package strdist_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewKeyboardLayout(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		rows []strdist.KeyboardRow
+	}{
+		{
+			ID: testhelper.MkID("good"),
+			rows: []strdist.KeyboardRow{
+				{Keys: "abc", Shifted: "ABC"},
+				{Offset: 0.5, Keys: "def"},
+			},
+		},
+		{
+			ID: testhelper.MkID("bad: shifted keys mismatch"),
+			rows: []strdist.KeyboardRow{
+				{Keys: "abc", Shifted: "AB"},
+			},
+			ExpErr: testhelper.MkExpErr(`keyboard layout "test", row 0:`,
+				`the shifted keys ("AB") must match the keys ("abc")`),
+		},
+		{
+			ID: testhelper.MkID("bad: duplicate key"),
+			rows: []strdist.KeyboardRow{
+				{Keys: "abc"},
+				{Keys: "dea"},
+			},
+			ExpErr: testhelper.MkExpErr(`keyboard layout "test", row 1:`,
+				`duplicate key: 'a'`),
+		},
+	}
+
+	for _, tc := range testCases {
+		kl, err := strdist.NewKeyboardLayout("test", tc.rows...)
+		if testhelper.CheckExpErr(t, err, tc) && err == nil {
+			testhelper.DiffString(t, tc.IDStr(), "name", kl.Name(), "test")
+		}
+	}
+}
+
+func TestKeyDistance(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		kl      *strdist.KeyboardLayout
+		r1, r2  rune
+		expDist float64
+		expOK   bool
+	}{
+		{
+			ID:      testhelper.MkID("QWERTY: same row"),
+			kl:      strdist.KeyboardQWERTY,
+			r1:      'f',
+			r2:      'g',
+			expDist: 1,
+			expOK:   true,
+		},
+		{
+			ID:      testhelper.MkID("QWERTY: shifted"),
+			kl:      strdist.KeyboardQWERTY,
+			r1:      'G',
+			r2:      'g',
+			expDist: 0,
+			expOK:   true,
+		},
+		{
+			ID:      testhelper.MkID("QWERTY: row below"),
+			kl:      strdist.KeyboardQWERTY,
+			r1:      'g',
+			r2:      'b',
+			expDist: 1.118034,
+			expOK:   true,
+		},
+		{
+			ID:      testhelper.MkID("QWERTY: not on the keyboard"),
+			kl:      strdist.KeyboardQWERTY,
+			r1:      'g',
+			r2:      'é',
+			expDist: 0,
+			expOK:   false,
+		},
+		{
+			ID:      testhelper.MkID("AZERTY: accented"),
+			kl:      strdist.KeyboardAZERTY,
+			r1:      'é',
+			r2:      'z',
+			expDist: 1.118034,
+			expOK:   true,
+		},
+		{
+			ID:      testhelper.MkID("Dvorak: same row"),
+			kl:      strdist.KeyboardDvorak,
+			r1:      'a',
+			r2:      'o',
+			expDist: 1,
+			expOK:   true,
+		},
+		{
+			ID:      testhelper.MkID("QWERTZ: y is bottom left"),
+			kl:      strdist.KeyboardQWERTZ,
+			r1:      'y',
+			r2:      'x',
+			expDist: 1,
+			expOK:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		d, ok := tc.kl.KeyDistance(tc.r1, tc.r2)
+		testhelper.DiffFloat(t, tc.IDStr(), "distance", d, tc.expDist, 0.00001)
+		testhelper.DiffBool(t, tc.IDStr(), "ok", ok, tc.expOK)
+	}
+}
+
+func TestKeyboardCostModel(t *testing.T) {
+	kcm := strdist.NewKeyboardCostModel(strdist.KeyboardQWERTY)
+
+	testCases := []struct {
+		testhelper.ID
+		from, to rune
+		expCost  float64
+	}{
+		{ID: testhelper.MkID("adjacent"), from: 't', to: 'r', expCost: 0.5},
+		{ID: testhelper.MkID("diagonal"), from: 't', to: 'g', expCost: 0.5},
+		{ID: testhelper.MkID("shifted"), from: 't', to: 'T', expCost: 0.5},
+		{ID: testhelper.MkID("distant"), from: 't', to: 'p', expCost: 1},
+		{ID: testhelper.MkID("not on keyboard"), from: 't', to: 'ü', expCost: 1},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffFloat(t, tc.IDStr(), "substitution cost",
+			kcm.SubCost(tc.from, tc.to), tc.expCost, 0)
+	}
+
+	badKCM := *kcm
+	badKCM.AdjacentCost = -1
+	_, err := strdist.NewKeyboardAlgo(&badKCM)
+	testhelper.CheckExpErrWithID(t, "bad adjacent cost", err,
+		testhelper.MkExpErr(
+			"the adjacent key substitution cost (-1.000000) must be >= 0"))
+
+	badKCM = *kcm
+	badKCM.Layout = nil
+	_, err = strdist.NewKeyboardAlgo(&badKCM)
+	testhelper.CheckExpErrWithID(t, "no layout", err,
+		testhelper.MkExpErr("the keyboard Layout must not be nil"))
+
+	_, err = strdist.NewKeyboardAlgo(nil)
+	testhelper.CheckExpErrWithID(t, "nil cost model", err,
+		testhelper.MkExpErr("the KeyboardCostModel must not be nil"))
+}
+
+func TestKeyboardSuggestions(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		val    string
+		alts   []string
+		expVal []string
+	}{
+		{
+			ID:     testhelper.MkID("transposition"),
+			val:    "stauts",
+			alts:   []string{"state", "stats", "start", "status"},
+			expVal: []string{"status", "stats", "state"},
+		},
+		{
+			ID:     testhelper.MkID("transposition before fat finger"),
+			val:    "gti",
+			alts:   []string{"gtk", "gui", "git"},
+			expVal: []string{"git", "gtk", "gui"},
+		},
+		{
+			ID:     testhelper.MkID("fat finger"),
+			val:    "cimmit",
+			alts:   []string{"commit", "summit", "vomit"},
+			expVal: []string{"commit", "summit", "vomit"},
+		},
+	}
+
+	f := strdist.DefaultFinders[strdist.CaseBlindAlgoNameKeyboard]
+
+	for _, tc := range testCases {
+		vals := strdist.SuggestedValsWithFinder(f, tc.val, tc.alts)
+		testhelper.DiffStringSlice(t, tc.IDStr(), "suggestions",
+			vals, tc.expVal)
+	}
+}
+
+func TestSuggestedVals(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		val    string
+		alts   []string
+		expVal []string
+	}{
+		{
+			ID:     testhelper.MkID("short value"),
+			val:    "gti",
+			alts:   []string{"gtk", "gui", "git", "cat"},
+			expVal: []string{"git", "gtk", "gui"},
+		},
+		{
+			ID:     testhelper.MkID("typos only"),
+			val:    "stauts",
+			alts:   []string{"state", "stats", "start", "status"},
+			expVal: []string{"status", "stats"},
+		},
+		{
+			ID:  testhelper.MkID("typos then cosine"),
+			val: "colour-scheme",
+			alts: []string{
+				"color-scheme", "colour-schema", "scheme-colour", "colours",
+			},
+			expVal: []string{"colour-schema", "color-scheme", "scheme-colour"},
+		},
+	}
+
+	for _, tc := range testCases {
+		vals := strdist.SuggestedVals(tc.val, tc.alts)
+		testhelper.DiffStringSlice(t, tc.IDStr(), "suggestions",
+			vals, tc.expVal)
+	}
+}
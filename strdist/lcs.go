@@ -0,0 +1,278 @@
+package strdist
+
+// LCSAlgo encapsulates the details needed to provide the Longest Common
+// Subsequence (LCS) distance. This is the number of runes which must be
+// inserted or deleted to transform one string into the other (sometimes
+// called the indel distance). Unlike the Levenshtein distance, substitution
+// is not allowed and so costs two operations (a deletion and an
+// insertion). This makes it suitable for comparing strings where insertions
+// are common but substitutions are rare, such as file paths and code
+// identifiers.
+type LCSAlgo struct{}
+
+// Name returns the algorithm name
+func (LCSAlgo) Name() string {
+	return AlgoNameLCS
+}
+
+// Desc returns a string describing the algorithm configuration
+func (LCSAlgo) Desc() string {
+	return ""
+}
+
+// Dist for a LCSAlgo will calculate the LCS distance between the two strings
+func (LCSAlgo) Dist(s1, s2 string) float64 {
+	return float64(LCSDistance(s1, s2))
+}
+
+// NormalizedDist for a LCSAlgo gives the scaled LCS distance (see
+// ScaledLCSDistance)
+func (LCSAlgo) NormalizedDist(s1, s2 string) float64 {
+	return ScaledLCSDistance(s1, s2)
+}
+
+// ScaledLCSAlgo encapsulates the details needed to provide the scaled LCS
+// distance.
+type ScaledLCSAlgo struct{}
+
+// Name returns the algorithm name
+func (ScaledLCSAlgo) Name() string {
+	return AlgoNameScaledLCS
+}
+
+// Desc returns a string describing the algorithm configuration
+func (ScaledLCSAlgo) Desc() string {
+	return ""
+}
+
+// Dist for a ScaledLCSAlgo will calculate the scaled LCS distance between
+// the two strings
+func (ScaledLCSAlgo) Dist(s1, s2 string) float64 {
+	return ScaledLCSDistance(s1, s2)
+}
+
+// NormalizedDist for a ScaledLCSAlgo is the same as Dist
+func (a ScaledLCSAlgo) NormalizedDist(s1, s2 string) float64 {
+	return a.Dist(s1, s2)
+}
+
+// LCSDistance calculates the LCS (or indel) distance between strings a and
+// b. This is the sum of the lengths (in runes) of the two strings less twice
+// the length of their longest common subsequence.
+func LCSDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	return len(ra) + len(rb) - 2*lcsLen(ra, rb)
+}
+
+// ScaledLCSDistance calculates the scaled LCS distance between strings a and
+// b. This is the LCS distance divided by the sum of the lengths of the two
+// strings, giving a value between 0 and 1. Two zero-length strings are taken
+// as identical (with a zero distance between them)
+func ScaledLCSDistance(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	totLen := len(ra) + len(rb)
+	if totLen == 0 {
+		return 0.0
+	}
+
+	return float64(totLen-2*lcsLen(ra, rb)) / float64(totLen)
+}
+
+// lcsLen returns the length of the longest common subsequence of a and
+// b. It only keeps two rows of the table of sub-problem results and so uses
+// memory proportional to the length of b.
+func lcsLen(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for _, aRune := range a {
+		for j, bRune := range b {
+			if aRune == bRune {
+				curr[j+1] = prev[j] + 1
+			} else {
+				curr[j+1] = max(prev[j+1], curr[j])
+			}
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// LongestCommonSubsequence returns the longest common subsequence of strings
+// a and b together with the positions of its runes in each string. The
+// positions are rune offsets (not byte offsets) into a and b and are in
+// increasing order. Where there is more than one longest common subsequence
+// only one will be returned.
+func LongestCommonSubsequence(a, b string) (lcs string, aPos, bPos []int) {
+	ra, rb := []rune(a), []rune(b)
+
+	// l[i][j] holds the length of the LCS of ra[i:] and rb[j:]
+	l := make([][]int, len(ra)+1)
+	for i := range l {
+		l[i] = make([]int, len(rb)+1)
+	}
+
+	for i := len(ra) - 1; i >= 0; i-- {
+		for j := len(rb) - 1; j >= 0; j-- {
+			if ra[i] == rb[j] {
+				l[i][j] = l[i+1][j+1] + 1
+			} else {
+				l[i][j] = max(l[i+1][j], l[i][j+1])
+			}
+		}
+	}
+
+	lcsRunes := make([]rune, 0, l[0][0])
+	aPos = make([]int, 0, l[0][0])
+	bPos = make([]int, 0, l[0][0])
+
+	for i, j := 0, 0; i < len(ra) && j < len(rb); {
+		switch {
+		case ra[i] == rb[j]:
+			lcsRunes = append(lcsRunes, ra[i])
+			aPos = append(aPos, i)
+			bPos = append(bPos, j)
+			i++
+			j++
+		case l[i+1][j] >= l[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return string(lcsRunes), aPos, bPos
+}
+
+// LongestCommonSubstringAlgo encapsulates the details needed to provide the
+// longest common substring distance. This is the number of runes in the two
+// strings which are not part of their longest common substring.
+type LongestCommonSubstringAlgo struct{}
+
+// Name returns the algorithm name
+func (LongestCommonSubstringAlgo) Name() string {
+	return AlgoNameLCSubstr
+}
+
+// Desc returns a string describing the algorithm configuration
+func (LongestCommonSubstringAlgo) Desc() string {
+	return ""
+}
+
+// Dist for a LongestCommonSubstringAlgo will calculate the longest common
+// substring distance between the two strings
+func (LongestCommonSubstringAlgo) Dist(s1, s2 string) float64 {
+	return float64(LongestCommonSubstringDistance(s1, s2))
+}
+
+// NormalizedDist for a LongestCommonSubstringAlgo gives the scaled longest
+// common substring distance (see ScaledLongestCommonSubstringDistance)
+func (LongestCommonSubstringAlgo) NormalizedDist(s1, s2 string) float64 {
+	return ScaledLongestCommonSubstringDistance(s1, s2)
+}
+
+// ScaledLongestCommonSubstringAlgo encapsulates the details needed to
+// provide the scaled longest common substring distance.
+type ScaledLongestCommonSubstringAlgo struct{}
+
+// Name returns the algorithm name
+func (ScaledLongestCommonSubstringAlgo) Name() string {
+	return AlgoNameScaledLCSubstr
+}
+
+// Desc returns a string describing the algorithm configuration
+func (ScaledLongestCommonSubstringAlgo) Desc() string {
+	return ""
+}
+
+// Dist for a ScaledLongestCommonSubstringAlgo will calculate the scaled
+// longest common substring distance between the two strings
+func (ScaledLongestCommonSubstringAlgo) Dist(s1, s2 string) float64 {
+	return ScaledLongestCommonSubstringDistance(s1, s2)
+}
+
+// NormalizedDist for a ScaledLongestCommonSubstringAlgo is the same as Dist
+func (a ScaledLongestCommonSubstringAlgo) NormalizedDist(s1, s2 string,
+) float64 {
+	return a.Dist(s1, s2)
+}
+
+// LongestCommonSubstringDistance calculates the longest common substring
+// distance between strings a and b. This is the sum of the lengths (in
+// runes) of the two strings less twice the length of their longest common
+// substring.
+func LongestCommonSubstringDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	_, _, subLen := longestCommonSubstring(ra, rb)
+
+	return len(ra) + len(rb) - 2*subLen
+}
+
+// ScaledLongestCommonSubstringDistance calculates the scaled longest common
+// substring distance between strings a and b. This is the longest common
+// substring distance divided by the sum of the lengths of the two strings,
+// giving a value between 0 and 1. Two zero-length strings are taken as
+// identical (with a zero distance between them)
+func ScaledLongestCommonSubstringDistance(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	totLen := len(ra) + len(rb)
+	if totLen == 0 {
+		return 0.0
+	}
+
+	_, _, subLen := longestCommonSubstring(ra, rb)
+
+	return float64(totLen-2*subLen) / float64(totLen)
+}
+
+// LongestCommonSubstring returns the longest common substring of strings a
+// and b together with its starting positions in each string. The positions
+// are rune offsets (not byte offsets) into a and b. If there is no common
+// substring the returned positions are both -1. Where there is more than one
+// longest common substring the one appearing first in a is returned.
+func LongestCommonSubstring(a, b string) (sub string, aStart, bStart int) {
+	ra, rb := []rune(a), []rune(b)
+
+	aStart, bStart, subLen := longestCommonSubstring(ra, rb)
+	if subLen == 0 {
+		return "", -1, -1
+	}
+
+	return string(ra[aStart : aStart+subLen]), aStart, bStart
+}
+
+// longestCommonSubstring returns the start positions in a and b of the
+// longest common substring and its length. It only keeps two rows of the
+// table of sub-problem results and so uses memory proportional to the
+// length of b.
+func longestCommonSubstring(a, b []rune) (aStart, bStart, subLen int) {
+	// prev[j+1] and curr[j+1] hold the length of the longest common suffix
+	// of a[:i] and b[:j+1] for the previous and current values of i
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for i, aRune := range a {
+		for j, bRune := range b {
+			if aRune != bRune {
+				curr[j+1] = 0
+				continue
+			}
+
+			curr[j+1] = prev[j] + 1
+			if curr[j+1] > subLen {
+				subLen = curr[j+1]
+				aStart = i + 1 - subLen
+				bStart = j + 1 - subLen
+			}
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return aStart, bStart, subLen
+}
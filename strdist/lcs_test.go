@@ -0,0 +1,196 @@
+package strdist_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestLCS(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b          string
+		expLCS        string
+		expAPos       []int
+		expBPos       []int
+		expDist       int
+		expScaledDist float64
+	}{
+		{
+			ID:      testhelper.MkID("both empty"),
+			expAPos: []int{},
+			expBPos: []int{},
+		},
+		{
+			ID:            testhelper.MkID("one empty"),
+			a:             "abc",
+			expAPos:       []int{},
+			expBPos:       []int{},
+			expDist:       3,
+			expScaledDist: 1,
+		},
+		{
+			ID:            testhelper.MkID("nothing in common"),
+			a:             "abc",
+			b:             "xyz",
+			expAPos:       []int{},
+			expBPos:       []int{},
+			expDist:       6,
+			expScaledDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("identical"),
+			a:       "abc",
+			b:       "abc",
+			expLCS:  "abc",
+			expAPos: []int{0, 1, 2},
+			expBPos: []int{0, 1, 2},
+		},
+		{
+			ID:            testhelper.MkID("Kitten/Sitting"),
+			a:             "kitten",
+			b:             "sitting",
+			expLCS:        "ittn",
+			expAPos:       []int{1, 2, 3, 5},
+			expBPos:       []int{1, 2, 3, 5},
+			expDist:       5,
+			expScaledDist: 5.0 / 13.0,
+		},
+		{
+			ID:            testhelper.MkID("classic"),
+			a:             "ABCBDAB",
+			b:             "BDCABA",
+			expLCS:        "BDAB",
+			expAPos:       []int{1, 4, 5, 6},
+			expBPos:       []int{0, 1, 3, 4},
+			expDist:       5,
+			expScaledDist: 5.0 / 13.0,
+		},
+		{
+			ID:            testhelper.MkID("multi-byte runes"),
+			a:             "§¶ab",
+			b:             "x§¶",
+			expLCS:        "§¶",
+			expAPos:       []int{0, 1},
+			expBPos:       []int{1, 2},
+			expDist:       3,
+			expScaledDist: 3.0 / 7.0,
+		},
+	}
+
+	for _, tc := range testCases {
+		const epsilon = 0.00001
+
+		lcs, aPos, bPos := strdist.LongestCommonSubsequence(tc.a, tc.b)
+		testhelper.DiffString(t, tc.IDStr(), "LCS", lcs, tc.expLCS)
+		testhelper.DiffSlice(t, tc.IDStr(), "a positions", aPos, tc.expAPos)
+		testhelper.DiffSlice(t, tc.IDStr(), "b positions", bPos, tc.expBPos)
+
+		for _, ab := range [][2]string{{tc.a, tc.b}, {tc.b, tc.a}} {
+			testhelper.DiffInt(t, tc.IDStr(),
+				fmt.Sprintf("LCSDistance(%q, %q)", ab[0], ab[1]),
+				strdist.LCSDistance(ab[0], ab[1]), tc.expDist)
+			testhelper.DiffFloat(t, tc.IDStr(),
+				fmt.Sprintf("ScaledLCSDistance(%q, %q)", ab[0], ab[1]),
+				strdist.ScaledLCSDistance(ab[0], ab[1]),
+				tc.expScaledDist, epsilon)
+			testhelper.DiffFloat(t, tc.IDStr(),
+				fmt.Sprintf("LCSAlgo.Dist(%q, %q)", ab[0], ab[1]),
+				strdist.LCSAlgo{}.Dist(ab[0], ab[1]),
+				float64(tc.expDist), 0)
+		}
+	}
+}
+
+func TestLongestCommonSubstring(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b          string
+		expSub        string
+		expAStart     int
+		expBStart     int
+		expDist       int
+		expScaledDist float64
+	}{
+		{
+			ID:        testhelper.MkID("both empty"),
+			expAStart: -1,
+			expBStart: -1,
+		},
+		{
+			ID:            testhelper.MkID("nothing in common"),
+			a:             "abc",
+			b:             "xyz",
+			expAStart:     -1,
+			expBStart:     -1,
+			expDist:       6,
+			expScaledDist: 1,
+		},
+		{
+			ID:            testhelper.MkID("common middle"),
+			a:             "xabcdy",
+			b:             "zzabcdw",
+			expSub:        "abcd",
+			expAStart:     1,
+			expBStart:     2,
+			expDist:       5,
+			expScaledDist: 5.0 / 13.0,
+		},
+		{
+			ID:            testhelper.MkID("first of equal length"),
+			a:             "ABCBDAB",
+			b:             "BDCABA",
+			expSub:        "AB",
+			expAStart:     0,
+			expBStart:     3,
+			expDist:       9,
+			expScaledDist: 9.0 / 13.0,
+		},
+		{
+			ID:            testhelper.MkID("multi-byte runes"),
+			a:             "§¶ab",
+			b:             "x§¶",
+			expSub:        "§¶",
+			expAStart:     0,
+			expBStart:     1,
+			expDist:       3,
+			expScaledDist: 3.0 / 7.0,
+		},
+	}
+
+	for _, tc := range testCases {
+		const epsilon = 0.00001
+
+		sub, aStart, bStart := strdist.LongestCommonSubstring(tc.a, tc.b)
+		testhelper.DiffString(t, tc.IDStr(), "substring", sub, tc.expSub)
+		testhelper.DiffInt(t, tc.IDStr(), "a start", aStart, tc.expAStart)
+		testhelper.DiffInt(t, tc.IDStr(), "b start", bStart, tc.expBStart)
+
+		for _, ab := range [][2]string{{tc.a, tc.b}, {tc.b, tc.a}} {
+			testhelper.DiffInt(t, tc.IDStr(),
+				fmt.Sprintf("LongestCommonSubstringDistance(%q, %q)",
+					ab[0], ab[1]),
+				strdist.LongestCommonSubstringDistance(ab[0], ab[1]),
+				tc.expDist)
+			testhelper.DiffFloat(t, tc.IDStr(),
+				fmt.Sprintf("ScaledLongestCommonSubstringDistance(%q, %q)",
+					ab[0], ab[1]),
+				strdist.ScaledLongestCommonSubstringAlgo{}.Dist(ab[0], ab[1]),
+				tc.expScaledDist, epsilon)
+		}
+	}
+}
+
+func TestLCSFinder(t *testing.T) {
+	pop := []string{
+		"strdist/finderConfig.go",
+		"strdist/finder.go",
+		"cmd/mkbadge/main.c",
+	}
+	f := strdist.DefaultFinders[strdist.AlgoNameScaledLCS]
+	finderChecker(t, "scaled LCS", "default finder",
+		"strdist/finderCfg.go", pop, f,
+		[]string{"strdist/finderConfig.go", "strdist/finder.go"})
+}
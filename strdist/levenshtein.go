@@ -1,11 +1,5 @@
 package strdist
 
-import (
-	"unicode/utf8"
-
-	"github.com/nickwells/mathutil.mod/v2/mathutil"
-)
-
 // LevenshteinAlgo encapsulates the details needed to provide the Levenshtein
 // distance.
 type LevenshteinAlgo struct{}
@@ -26,36 +20,90 @@ func (LevenshteinAlgo) Dist(s1, s2 string) float64 {
 	return float64(LevenshteinDistance(s1, s2))
 }
 
+// NormalizedDist for a LevenshteinAlgo gives the scaled Levenshtein
+// distance (see ScaledLevDistance)
+func (LevenshteinAlgo) NormalizedDist(s1, s2 string) float64 {
+	return ScaledLevDistance(s1, s2)
+}
+
 // LevenshteinDistance calculates the Levenshtein distance between strings a
 // and b
 func LevenshteinDistance(a, b string) int {
-	aLen := utf8.RuneCountInString(a)
-	bLen := utf8.RuneCountInString(b)
-	d := make([][]int, aLen+1)
+	d, _ := levenshtein([]rune(a), []rune(b))
+
+	return d
+}
 
-	for i := range d {
-		d[i] = make([]int, bLen+1)
-		d[i][0] = i
+// levenshtein calculates the Levenshtein distance between a and b. It also
+// returns the length of the longest alignment of a and b having that
+// distance; this is the number of matches, substitutions, insertions and
+// deletions in the alignment. It only keeps two rows of the table of
+// sub-problem results, each as long as the shorter of a and b, and so uses
+// memory proportional to the length of the shorter string.
+func levenshtein(a, b []rune) (dist, alignLen int) {
+	if len(a) < len(b) {
+		a, b = b, a
 	}
 
-	for i := 1; i <= bLen; i++ {
-		d[0][i] = i
+	// the aux values are the lengths of the alignments
+	prev, curr := newLevRow(len(b)), newLevRow(len(b))
+
+	for j := range prev.dist {
+		prev.dist[j] = j
+		prev.aux[j] = j
+	}
+
+	for i, aRune := range a {
+		curr.dist[0] = i + 1
+		curr.aux[0] = i + 1
+
+		levRowStep(aRune, b, prev, curr, 1)
+
+		prev, curr = curr, prev
 	}
 
+	return prev.dist[len(b)], prev.aux[len(b)]
+}
+
+// levRow holds one row of the table of Levenshtein sub-problem results. For
+// each prefix of the second string it holds the distance and an auxiliary
+// value carried along the path of edits giving that distance.
+type levRow struct {
+	dist []int
+	aux  []int
+}
+
+// newLevRow returns a levRow for a second string of length n
+func newLevRow(n int) levRow {
+	return levRow{
+		dist: make([]int, n+1),
+		aux:  make([]int, n+1),
+	}
+}
+
+// levRowStep calculates the curr row of the Levenshtein table, for the next
+// rune (r) of the first string, from the prev row; b is the second string.
+// The first entry of the curr row must already be set. The auxInc is added
+// to the auxiliary value at each edit or match and, where several paths
+// give the same distance, the largest auxiliary value is kept.
+func levRowStep(r rune, b []rune, prev, curr levRow, auxInc int) {
 	for j, bRune := range b {
-		for i, aRune := range a {
-			var subsCost int
-			if aRune != bRune {
-				subsCost = 1
-			}
+		var subsCost int
+		if r != bRune {
+			subsCost = 1
+		}
 
-			del := d[i][j+1] + 1
-			ins := d[i+1][j] + 1
-			sub := d[i][j] + subsCost
+		d, l := prev.dist[j]+subsCost, prev.aux[j]+auxInc
 
-			d[i+1][j+1] = mathutil.MinOfInt(del, ins, sub)
+		for _, alt := range [2][2]int{
+			{prev.dist[j+1] + 1, prev.aux[j+1] + auxInc}, // deletion
+			{curr.dist[j] + 1, curr.aux[j] + auxInc},     // insertion
+		} {
+			if alt[0] < d || (alt[0] == d && alt[1] > l) {
+				d, l = alt[0], alt[1]
+			}
 		}
-	}
 
-	return d[aLen][bLen]
+		curr.dist[j+1], curr.aux[j+1] = d, l
+	}
 }
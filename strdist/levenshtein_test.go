@@ -2,6 +2,7 @@ package strdist_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/nickwells/strdist.mod/v2/strdist"
@@ -51,6 +52,24 @@ func TestLevenshtein(t *testing.T) {
 			b:       "Sunday",
 			expDist: 3,
 		},
+		{
+			ID:      testhelper.MkID("multi-byte, deletion"),
+			a:       "a§¶b",
+			b:       "a¶b",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("multi-byte, substitution"),
+			a:       "café",
+			b:       "cafè",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("multi-byte, all differ"),
+			a:       "日本語",
+			b:       "中文",
+			expDist: 3,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -154,3 +173,43 @@ func TestLevenshteinFinder(t *testing.T) {
 			flatCaseFinder, tc.expNStringsFlatCase)
 	}
 }
+
+// benchStrings returns a pair of long, similar strings for the benchmarks
+func benchStrings() (string, string) {
+	const reps = 100
+
+	a := strings.Repeat("the quick brown fox jumps over the lazy dog. ", reps)
+	b := strings.Repeat("the quick brown fax jumped over a lazy dog! ", reps)
+
+	return a, b
+}
+
+func BenchmarkLevenshteinDistance(b *testing.B) {
+	s1, s2 := benchStrings()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		strdist.LevenshteinDistance(s1, s2)
+	}
+}
+
+func BenchmarkScaledLevDistance(b *testing.B) {
+	s1, s2 := benchStrings()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		strdist.ScaledLevDistance(s1, s2)
+	}
+}
+
+func BenchmarkLevenshteinAlignment(b *testing.B) {
+	s1, s2 := benchStrings()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		strdist.LevenshteinAlignment(s1, s2)
+	}
+}
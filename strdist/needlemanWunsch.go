@@ -0,0 +1,83 @@
+package strdist
+
+// NeedlemanWunschAlgo encapsulates the details needed to provide the
+// Needleman-Wunsch distance. This finds the best global alignment of the
+// two strings, aligning the whole of each string. The scores are given by
+// the AlignScores, which can include a substitution matrix, and affine gap
+// penalties (Gotoh's algorithm) are supported. Affine gaps make the
+// insertion or removal of a whole chunk of runes cheaper than the same
+// number of scattered single-rune edits.
+//
+// The distance is 1 minus the score of the best global alignment divided by
+// the score of the longer string aligned with itself, limited to lie
+// between 0 and 1. Identical strings have a zero distance.
+type NeedlemanWunschAlgo struct {
+	sc AlignScores
+}
+
+// NewNeedlemanWunschAlgo returns a new NeedlemanWunschAlgo with the scores
+// set. The scores must be valid.
+func NewNeedlemanWunschAlgo(sc AlignScores) (*NeedlemanWunschAlgo, error) {
+	if err := sc.Check(); err != nil {
+		return nil, err
+	}
+
+	return &NeedlemanWunschAlgo{sc: sc}, nil
+}
+
+// NewNeedlemanWunschAlgoOrPanic returns a new NeedlemanWunschAlgo. It will
+// panic if the algo cannot be created without errors.
+func NewNeedlemanWunschAlgoOrPanic(sc AlignScores) *NeedlemanWunschAlgo {
+	a, err := NewNeedlemanWunschAlgo(sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// Name returns the algorithm name
+func (NeedlemanWunschAlgo) Name() string {
+	return AlgoNameNeedlemanWunsch
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a NeedlemanWunschAlgo) Desc() string {
+	return a.sc.Desc()
+}
+
+// Dist for a NeedlemanWunschAlgo will calculate the Needleman-Wunsch
+// distance between the two strings
+func (a NeedlemanWunschAlgo) Dist(s1, s2 string) float64 {
+	ra, rb := []rune(s1), []rune(s2)
+
+	return globalDist(ra, rb, a.sc, gotohScore(ra, rb, a.sc, false))
+}
+
+// NormalizedDist for a NeedlemanWunschAlgo is the same as Dist
+func (a NeedlemanWunschAlgo) NormalizedDist(s1, s2 string) float64 {
+	return a.Dist(s1, s2)
+}
+
+// Alignment returns the best global alignment of the two strings. See
+// NeedlemanWunschAlignment for details.
+func (a NeedlemanWunschAlgo) Alignment(s1, s2 string) ScoredAlignment {
+	return NeedlemanWunschAlignment(s1, s2, a.sc)
+}
+
+// NeedlemanWunschAlignment returns the best global alignment of strings a
+// and b using the scores given. The Dist of the alignment is the
+// normalised distance as given by the NeedlemanWunschAlgo. The scores are
+// not checked and should be valid.
+func NeedlemanWunschAlignment(a, b string, sc AlignScores) ScoredAlignment {
+	ra, rb := []rune(a), []rune(b)
+	t := newGotohTable(ra, rb, sc, false)
+
+	sa := ScoredAlignment{
+		Alignment: Alignment{Dist: globalDist(ra, rb, sc, t.bestScore)},
+		Score:     t.bestScore,
+	}
+	sa.Edits, _, _ = t.traceback()
+
+	return sa
+}
@@ -0,0 +1,244 @@
+package strdist_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewNeedlemanWunschAlgo(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		sc      strdist.AlignScores
+		expDesc string
+	}{
+		{
+			ID:      testhelper.MkID("default scores"),
+			sc:      strdist.DfltAlignScores,
+			expDesc: "Match: 2 Mismatch: -1 GapOpen: 2 GapExtend: 1",
+		},
+		{
+			ID: testhelper.MkID("with substitution matrix"),
+			sc: strdist.AlignScores{
+				Match:     1,
+				Mismatch:  -1,
+				GapOpen:   1,
+				GapExtend: 1,
+				SubMatrix: map[[2]rune]float64{{'0', 'O'}: 0.5},
+			},
+			expDesc: "Match: 1 Mismatch: -1 GapOpen: 1 GapExtend: 1" +
+				" SubMatrix: 1 entries",
+		},
+		{
+			ID: testhelper.MkID("bad substitution matrix, self score"),
+			sc: strdist.AlignScores{
+				Match:     1,
+				Mismatch:  -1,
+				SubMatrix: map[[2]rune]float64{{'a', 'a'}: 0},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"the SubMatrix score for ('a', 'a') (0) must be > 0"),
+		},
+		{
+			ID: testhelper.MkID("bad substitution matrix, NaN"),
+			sc: strdist.AlignScores{
+				Match:     1,
+				Mismatch:  -1,
+				SubMatrix: map[[2]rune]float64{{'a', 'b'}: math.NaN()},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"the SubMatrix score for ('a', 'b') (NaN)" +
+					" must be a finite number"),
+		},
+	}
+
+	for _, tc := range testCases {
+		a, err := strdist.NewNeedlemanWunschAlgo(tc.sc)
+		if testhelper.CheckExpErr(t, err, tc) &&
+			err == nil {
+			testhelper.DiffString(t, tc.IDStr(), "Name",
+				a.Name(), strdist.AlgoNameNeedlemanWunsch)
+			testhelper.DiffString(t, tc.IDStr(), "Desc", a.Desc(), tc.expDesc)
+		}
+	}
+}
+
+func TestNeedlemanWunsch(t *testing.T) {
+	linearGaps := strdist.AlignScores{
+		Match:     2,
+		Mismatch:  -1,
+		GapOpen:   2,
+		GapExtend: 2,
+	}
+	ocrScores := strdist.AlignScores{
+		Match:     2,
+		Mismatch:  -1,
+		GapOpen:   2,
+		GapExtend: 1,
+		SubMatrix: map[[2]rune]float64{{'0', 'O'}: 1},
+	}
+
+	testCases := []struct {
+		testhelper.ID
+		sc        strdist.AlignScores
+		a, b      string
+		expDist   float64
+		expScore  float64
+		expMarkup string
+	}{
+		{
+			ID:        testhelper.MkID("both empty"),
+			sc:        strdist.DfltAlignScores,
+			expDist:   0,
+			expScore:  0,
+			expMarkup: "",
+		},
+		{
+			ID:        testhelper.MkID("one empty"),
+			sc:        strdist.DfltAlignScores,
+			a:         "abc",
+			expDist:   1,
+			expScore:  -4,
+			expMarkup: "[-abc]",
+		},
+		{
+			ID:        testhelper.MkID("identical"),
+			sc:        strdist.DfltAlignScores,
+			a:         "AB-1234",
+			b:         "AB-1234",
+			expDist:   0,
+			expScore:  14,
+			expMarkup: "AB-1234",
+		},
+		{
+			ID:        testhelper.MkID("nothing matches"),
+			sc:        strdist.DfltAlignScores,
+			a:         "abc",
+			b:         "xyz",
+			expDist:   1,
+			expScore:  -3,
+			expMarkup: "[-abc][+xyz]",
+		},
+		{
+			ID:        testhelper.MkID("affine gap"),
+			sc:        strdist.DfltAlignScores,
+			a:         "AB1234",
+			b:         "AB-XY-1234",
+			expDist:   1 - 7.0/20.0,
+			expScore:  7,
+			expMarkup: "AB[+-XY-]1234",
+		},
+		{
+			ID:        testhelper.MkID("linear gap"),
+			sc:        linearGaps,
+			a:         "AB1234",
+			b:         "AB-XY-1234",
+			expDist:   1 - 4.0/20.0,
+			expScore:  4,
+			expMarkup: "AB[+-XY-]1234",
+		},
+		{
+			ID:        testhelper.MkID("substitution matrix"),
+			sc:        ocrScores,
+			a:         "B00K",
+			b:         "BOOK",
+			expDist:   1 - 6.0/8.0,
+			expScore:  6,
+			expMarkup: "B[-00][+OO]K",
+		},
+		{
+			ID:        testhelper.MkID("no substitution matrix"),
+			sc:        strdist.DfltAlignScores,
+			a:         "B00K",
+			b:         "BOOK",
+			expDist:   1 - 2.0/8.0,
+			expScore:  2,
+			expMarkup: "B[-00][+OO]K",
+		},
+		{
+			ID:        testhelper.MkID("multi-byte"),
+			sc:        strdist.DfltAlignScores,
+			a:         "a§¶b",
+			b:         "a¶b",
+			expDist:   1 - 4.0/8.0,
+			expScore:  4,
+			expMarkup: "a[-§]¶b",
+		},
+	}
+
+	for _, tc := range testCases {
+		a := strdist.NewNeedlemanWunschAlgoOrPanic(tc.sc)
+
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("Dist(%q, %q)", tc.a, tc.b),
+			a.Dist(tc.a, tc.b), tc.expDist, 1e-12)
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("Dist(%q, %q)", tc.b, tc.a),
+			a.Dist(tc.b, tc.a), tc.expDist, 1e-12)
+
+		sa := a.Alignment(tc.a, tc.b)
+		testhelper.DiffFloat(t, tc.IDStr(), "alignment Dist",
+			sa.Dist, tc.expDist, 1e-12)
+		testhelper.DiffFloat(t, tc.IDStr(), "Score", sa.Score, tc.expScore, 0)
+		testhelper.DiffString(t, tc.IDStr(), "markup",
+			sa.Markup(strdist.BracketMarkup), tc.expMarkup)
+	}
+}
+
+func TestNeedlemanWunschFinder(t *testing.T) {
+	pop := []string{
+		"AB-1243",
+		"AB-12345",
+		"XY-9876",
+		"AB1234",
+	}
+	f := strdist.DefaultFinders[strdist.AlgoNameNeedlemanWunsch]
+	finderChecker(t, "Needleman-Wunsch", "default finder",
+		"AB-1234", pop, f,
+		[]string{"AB-12345", "AB1234"})
+}
+
+func TestNeedlemanWunschDistMatchesAlignment(t *testing.T) {
+	scores := map[string]strdist.AlignScores{
+		"default":   strdist.DfltAlignScores,
+		"linear":    {Match: 2, Mismatch: -1, GapOpen: 2, GapExtend: 2},
+		"free gaps": {Match: 1, Mismatch: -1},
+	}
+	pairs := [][2]string{
+		{"", "abc"},
+		{"abc", "abc"},
+		{"AB-1234", "AB1234"},
+		{"abcdef", "abcXYZdef"},
+		{"kitten", "sitting"},
+		{"§¶a", "ab§¶cd"},
+	}
+
+	for name, sc := range scores {
+		a := strdist.NewNeedlemanWunschAlgoOrPanic(sc)
+
+		for _, p := range pairs {
+			id := fmt.Sprintf("%s: %q, %q", name, p[0], p[1])
+			testhelper.DiffFloat(t, id, "Dist",
+				a.Dist(p[0], p[1]), a.Alignment(p[0], p[1]).Dist, 1e-12)
+		}
+	}
+}
+
+func TestNeedlemanWunschZeroValue(t *testing.T) {
+	a := strdist.NeedlemanWunschAlgo{}
+
+	testhelper.DiffFloat(t, "zero value", "Dist(same)",
+		a.Dist("abc", "abc"), 0, 0)
+	testhelper.DiffFloat(t, "zero value", "Dist(different)",
+		a.Dist("abc", "abd"), 1, 0)
+
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: strdist.DfltThresholdNeedlemanWunsch},
+		a)
+	finderChecker(t, "zero value", "Finder",
+		"abc", []string{"abd", "xyz", "abc"}, f, []string{"abc"})
+}
@@ -0,0 +1,50 @@
+package strdist
+
+import "math"
+
+// NormalizedAlgo is an Algo which can also give a normalised distance
+// between two strings. This is between 0 and 1, with identical strings
+// having a distance of 0, and so, unlike the distances given by Dist,
+// normalised distances from different algorithms are broadly comparable.
+// This allows a single threshold to be used whichever algorithm is chosen
+// (see the Normalized field of the FinderConfig). All the Algos in this
+// package are NormalizedAlgos.
+type NormalizedAlgo interface {
+	Algo
+	// NormalizedDist returns the normalised distance between the strings
+	NormalizedDist(s1, s2 string) float64
+}
+
+// distFunc is the type of a func giving the distance between two strings
+type distFunc func(s1, s2 string) float64
+
+// normalizedDistFunc returns the NormalizedDist func of the Algo if it is a
+// NormalizedAlgo. Otherwise it returns a func giving the Algo's Dist limited
+// to lie between 0 and 1.
+func normalizedDistFunc(a Algo) distFunc {
+	if na, ok := a.(NormalizedAlgo); ok {
+		return na.NormalizedDist
+	}
+
+	return func(s1, s2 string) float64 { return clamp01(a.Dist(s1, s2)) }
+}
+
+// clamp01 returns the value limited to lie between 0 and 1. A NaN value is
+// taken to be 1.
+func clamp01(d float64) float64 {
+	if math.IsNaN(d) {
+		return 1
+	}
+
+	return max(0, min(d, 1))
+}
+
+// normalizeByLen returns the distance divided by the length, or 0 if the
+// length is 0, limited to lie between 0 and 1
+func normalizeByLen(d float64, length int) float64 {
+	if length == 0 {
+		return 0
+	}
+
+	return clamp01(d / float64(length))
+}
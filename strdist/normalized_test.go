@@ -0,0 +1,262 @@
+package strdist_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNormalizedDist(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		algo    strdist.NormalizedAlgo
+		a, b    string
+		expDist float64
+	}{
+		{
+			ID:      testhelper.MkID("Levenshtein"),
+			algo:    strdist.LevenshteinAlgo{},
+			a:       "kitten",
+			b:       "sitting",
+			expDist: 3.0 / 7.0,
+		},
+		{
+			ID:      testhelper.MkID("Hamming"),
+			algo:    strdist.HammingAlgo{},
+			a:       "abcd",
+			b:       "abxy",
+			expDist: 0.5,
+		},
+		{
+			ID:      testhelper.MkID("Hamming, different lengths"),
+			algo:    strdist.HammingAlgo{},
+			a:       "abcd",
+			b:       "ab",
+			expDist: 0.5,
+		},
+		{
+			ID:      testhelper.MkID("Hamming, strict, different lengths"),
+			algo:    strdist.HammingAlgo{Strict: true},
+			a:       "abcd",
+			b:       "ab",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("Hamming, bits"),
+			algo:    strdist.HammingAlgo{Bits: true},
+			a:       "a",
+			b:       "b",
+			expDist: 2.0 / 8.0,
+		},
+		{
+			ID:      testhelper.MkID("LCS"),
+			algo:    strdist.LCSAlgo{},
+			a:       "abcd",
+			b:       "acd",
+			expDist: 1.0 / 7.0,
+		},
+		{
+			ID:      testhelper.MkID("longest common substring"),
+			algo:    strdist.LongestCommonSubstringAlgo{},
+			a:       "abcd",
+			b:       "xbcy",
+			expDist: 4.0 / 8.0,
+		},
+		{
+			ID: testhelper.MkID("weighted Levenshtein"),
+			algo: strdist.NewWeightedLevenshteinAlgoOrPanic(
+				strdist.OpCosts{Ins: 1, Del: 2, Sub: 1, Trans: 1}),
+			a:       "abc",
+			b:       "ab",
+			expDist: 2.0 / (1 + 1 + 2),
+		},
+		{
+			ID: testhelper.MkID("token sort, normalised inner distance"),
+			algo: strdist.NewTokenSortAlgo(nil,
+				strdist.LevenshteinAlgo{}),
+			a:       "world hello",
+			b:       "hello word",
+			expDist: 1.0 / 11.0,
+		},
+		{
+			ID: testhelper.MkID("partial, normalised inner distance"),
+			algo: strdist.NewPartialAlgo(
+				strdist.LevenshteinAlgo{}),
+			a:       "yonkers",
+			b:       "new york yankees",
+			expDist: 2.0 / 7.0,
+		},
+		{
+			ID: testhelper.MkID("phonetic, normalised inner distance"),
+			algo: strdist.NewPhoneticAlgo(strdist.SoundexEncoder{},
+				strdist.LevenshteinAlgo{}),
+			a:       "Smith",
+			b:       "Smithers",
+			expDist: 1.0 / 4.0,
+		},
+		{
+			ID: testhelper.MkID("ensemble, normalised component distances"),
+			algo: strdist.NewEnsembleAlgoOrPanic(strdist.EnsembleWeightedMean,
+				strdist.EnsembleComponent{
+					Algo:   strdist.LevenshteinAlgo{},
+					Weight: 1,
+					Scale:  100,
+				},
+				strdist.EnsembleComponent{
+					Algo:   strdist.HammingAlgo{},
+					Weight: 1,
+				}),
+			a:       "abcd",
+			b:       "bcda",
+			expDist: (2.0/4.0 + 4.0/4.0) / 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffFloat(t, tc.IDStr(), "NormalizedDist",
+			tc.algo.NormalizedDist(tc.a, tc.b), tc.expDist, 1e-12)
+	}
+}
+
+func TestWeightedLevNormalizedDist(t *testing.T) {
+	wl := strdist.NewWeightedLevenshteinAlgoOrPanic(strdist.LevenshteinCosts)
+	lev := strdist.LevenshteinAlgo{}
+
+	for _, p := range [][2]string{
+		{"abc", "xyz"},
+		{"abc", "abd"},
+		{"abc", "ab"},
+		{"ab", "abcdef"},
+		{"kitten", "sitting"},
+		{"", "abc"},
+		{"héllo", "hello"},
+		{"same", "same"},
+	} {
+		id := fmt.Sprintf("%q v %q", p[0], p[1])
+		testhelper.DiffFloat(t, id, "NormalizedDist",
+			wl.NormalizedDist(p[0], p[1]), lev.NormalizedDist(p[0], p[1]),
+			1e-12)
+	}
+}
+
+func TestNormalizedDistRange(t *testing.T) {
+	pairs := [][2]string{
+		{"", ""},
+		{"", "abc"},
+		{"hello", "hello"},
+		{"abcdefg", "abcdefg"},
+		{"a§¶b", "a§¶b"},
+		{"hello", "world"},
+		{"New York Mets", "Mets New York"},
+		{"Smith", "Schmidt"},
+		{"a§¶b", "a¶b"},
+	}
+
+	for name, f := range strdist.DefaultFinders {
+		na, ok := f.Algo.(strdist.NormalizedAlgo)
+		if !ok {
+			t.Errorf("%s: the Algo is not a NormalizedAlgo", name)
+			continue
+		}
+
+		for _, p := range pairs {
+			id := fmt.Sprintf("%s: %q, %q", name, p[0], p[1])
+			d := na.NormalizedDist(p[0], p[1])
+
+			if d < 0 || d > 1 {
+				t.Errorf("%s: the distance (%g) is not in [0,1]", id, d)
+			}
+
+			if p[0] == p[1] {
+				testhelper.DiffFloat(t, id, "NormalizedDist", d, 0, 0)
+			}
+		}
+	}
+}
+
+func TestNormalizedFinder(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		fc   strdist.FinderConfig
+		algo strdist.Algo
+	}{
+		{
+			ID: testhelper.MkID("good"),
+			fc: strdist.FinderConfig{
+				Threshold:  0.3,
+				Normalized: true,
+			},
+			algo: strdist.LevenshteinAlgo{},
+		},
+		{
+			ID: testhelper.MkID("bad threshold"),
+			fc: strdist.FinderConfig{
+				Threshold:  1.5,
+				Normalized: true,
+			},
+			algo: strdist.LevenshteinAlgo{},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the Threshold (1.500000) must be <= 1" +
+					" when distances are Normalized"),
+		},
+		{
+			ID: testhelper.MkID("not a NormalizedAlgo"),
+			fc: strdist.FinderConfig{
+				Threshold:  0.3,
+				Normalized: true,
+			},
+			algo: TestAlgo{},
+			ExpErr: testhelper.MkExpErr(
+				"the TestAlgo Algo must be a NormalizedAlgo" +
+					" when distances are Normalized"),
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := strdist.NewFinder(tc.fc, tc.algo)
+		testhelper.CheckExpErr(t, err, tc)
+	}
+
+	// the same threshold can be used with different algorithms
+	fc := strdist.FinderConfig{
+		Threshold:      0.34,
+		MinStrLength:   3,
+		MapToLowerCase: true,
+		Normalized:     true,
+	}
+	pop := []string{"Colour", "color", "collar", "cooler", "flavour"}
+
+	for _, tc := range []struct {
+		algo strdist.Algo
+		exp  []string
+	}{
+		{
+			algo: strdist.LevenshteinAlgo{},
+			exp:  []string{"Colour", "color", "collar"},
+		},
+		{
+			algo: strdist.LCSAlgo{},
+			exp:  []string{"Colour", "color", "collar", "cooler"},
+		},
+		{
+			algo: strdist.HammingAlgo{},
+			exp:  []string{"Colour", "collar", "color"},
+		},
+	} {
+		f := strdist.NewFinderOrPanic(fc, tc.algo)
+		finderChecker(t, tc.algo.Name(), "normalized finder",
+			"colour", pop, f, tc.exp)
+	}
+}
+
+func TestNormalizedCosineZeroThreshold(t *testing.T) {
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Normalized: true},
+		strdist.NewCosineAlgoOrPanic(strdist.DfltNGramConfig, 0))
+
+	finderChecker(t, "identical strings", "zero threshold",
+		"abcdefg", []string{"abcdefh", "abcdefg"}, f, []string{"abcdefg"})
+}
@@ -0,0 +1,88 @@
+package strdist
+
+// PartialAlgo encapsulates the details needed to provide the partial
+// distance. This compares the shorter of the two strings against every
+// substring of the longer string having the same length (in runes) and
+// returns the smallest distance found, as calculated by the inner Algo. This
+// finds the best matching alignment of the shorter string within the longer
+// one so, for instance, "yankees" and "new york yankees" will be identical.
+//
+// A PartialAlgo can be used as the inner Algo of a TokenSortAlgo or a
+// TokenSetAlgo to give the partial token sort or partial token set
+// distances.
+type PartialAlgo struct {
+	inner Algo
+}
+
+// NewPartialAlgo returns a new PartialAlgo. If the inner Algo is nil then a
+// ScaledLevAlgo is used.
+func NewPartialAlgo(inner Algo) *PartialAlgo {
+	if inner == nil {
+		inner = ScaledLevAlgo{}
+	}
+
+	return &PartialAlgo{inner: inner}
+}
+
+// Name returns the algorithm name
+func (PartialAlgo) Name() string {
+	return AlgoNamePartial
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a PartialAlgo) Desc() string {
+	return "Inner: " + algoDesc(a.inner)
+}
+
+// Dist for a PartialAlgo will calculate the smallest distance between the
+// shorter string and any same-length substring of the longer string. The
+// strings are passed to the inner Algo in the same order as they are given.
+func (a PartialAlgo) Dist(s1, s2 string) float64 {
+	return a.dist(s1, s2, a.inner.Dist)
+}
+
+// NormalizedDist for a PartialAlgo is as for Dist but uses the normalised
+// distances given by the inner Algo
+func (a PartialAlgo) NormalizedDist(s1, s2 string) float64 {
+	return a.dist(s1, s2, normalizedDistFunc(a.inner))
+}
+
+// dist calculates the distance using the given func to calculate the
+// inner distances
+func (a PartialAlgo) dist(s1, s2 string, innerDist distFunc) float64 {
+	r1, r2 := []rune(s1), []rune(s2)
+
+	shortFirst := true
+	if len(r1) > len(r2) {
+		shortFirst = false
+		r1, r2 = r2, r1
+	}
+
+	if len(r1) == 0 {
+		return innerDist(s1, s2)
+	}
+
+	short := string(r1)
+	best := -1.0
+
+	for i := 0; i+len(r1) <= len(r2); i++ {
+		window := string(r2[i : i+len(r1)])
+
+		var d float64
+		if shortFirst {
+			d = innerDist(short, window)
+		} else {
+			d = innerDist(window, short)
+		}
+
+		if best < 0 || d < best {
+			best = d
+		}
+
+		if best == 0 {
+			break
+		}
+	}
+
+	return best
+}
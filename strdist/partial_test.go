@@ -0,0 +1,58 @@
+package strdist_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestPartial(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b    string
+		expDist float64
+	}{
+		{
+			ID:      testhelper.MkID("both empty"),
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("one empty"),
+			a:       "abc",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("substring"),
+			a:       "yankees",
+			b:       "new york yankees",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("same length"),
+			a:       "abcd",
+			b:       "abxd",
+			expDist: 0.25,
+		},
+		{
+			ID:      testhelper.MkID("near substring"),
+			a:       "yanks",
+			b:       "new york yankees",
+			expDist: 0.2,
+		},
+	}
+
+	a := strdist.NewPartialAlgo(nil)
+
+	for _, tc := range testCases {
+		const epsilon = 0.00001
+
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("PartialAlgo.Dist(%q, %q)", tc.a, tc.b),
+			a.Dist(tc.a, tc.b), tc.expDist, epsilon)
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("PartialAlgo.Dist(%q, %q)", tc.b, tc.a),
+			a.Dist(tc.b, tc.a), tc.expDist, epsilon)
+	}
+}
@@ -0,0 +1,354 @@
+package strdist
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PhoneticEncoder describes the interface that a phonetic encoder must
+// satisfy. A phonetic encoder converts a string (typically a name) into a
+// code so that strings which sound alike have the same, or similar, codes.
+type PhoneticEncoder interface {
+	// Name returns the name of the encoding
+	Name() string
+	// Encode returns the phonetic code for the string
+	Encode(s string) string
+}
+
+// MultiPhoneticEncoder describes the interface that a phonetic encoder
+// giving more than one code for a string must satisfy. This allows for
+// strings having several plausible pronunciations.
+type MultiPhoneticEncoder interface {
+	PhoneticEncoder
+	// EncodeAll returns all the distinct phonetic codes for the string. The
+	// first code is the one returned by Encode.
+	EncodeAll(s string) []string
+}
+
+// phoneticCodes returns the phonetic codes for the string. If the encoder is
+// a MultiPhoneticEncoder then all the codes are returned, otherwise just the
+// one.
+func phoneticCodes(enc PhoneticEncoder, s string) []string {
+	if me, ok := enc.(MultiPhoneticEncoder); ok {
+		return me.EncodeAll(s)
+	}
+
+	return []string{enc.Encode(s)}
+}
+
+// SoundexEncoder is a PhoneticEncoder giving the Soundex code
+type SoundexEncoder struct{}
+
+// Name returns the name of the encoding
+func (SoundexEncoder) Name() string { return AlgoNameSoundex }
+
+// Encode returns the Soundex code for the string
+func (SoundexEncoder) Encode(s string) string { return Soundex(s) }
+
+// RefinedSoundexEncoder is a PhoneticEncoder giving the Refined Soundex code
+type RefinedSoundexEncoder struct{}
+
+// Name returns the name of the encoding
+func (RefinedSoundexEncoder) Name() string { return AlgoNameRefinedSoundex }
+
+// Encode returns the Refined Soundex code for the string
+func (RefinedSoundexEncoder) Encode(s string) string {
+	return RefinedSoundex(s)
+}
+
+// NYSIISEncoder is a PhoneticEncoder giving the NYSIIS code
+type NYSIISEncoder struct{}
+
+// Name returns the name of the encoding
+func (NYSIISEncoder) Name() string { return AlgoNameNYSIIS }
+
+// Encode returns the NYSIIS code for the string
+func (NYSIISEncoder) Encode(s string) string { return NYSIIS(s) }
+
+// phoneticLetters returns the letters of the string in upper case. Only the
+// letters A-Z are kept, everything else is discarded.
+func phoneticLetters(s string) []byte {
+	letters := make([]byte, 0, len(s))
+
+	for _, r := range s {
+		r = unicode.ToUpper(r)
+		if r >= 'A' && r <= 'Z' {
+			letters = append(letters, byte(r))
+		}
+	}
+
+	return letters
+}
+
+// soundexCodes gives the Soundex code for each letter A-Z. Vowels (and Y)
+// have the code '0' and H and W, which are ignored, have the code '-'.
+const soundexCodes = "0123012-02245501262301-202"
+
+// Soundex returns the American Soundex code for the string. This is the
+// first letter followed by three digits encoding the following consonants,
+// padded with zeros if necessary. Adjacent consonants with the same code
+// (including those separated only by H or W) are encoded once. Only the
+// letters A-Z are encoded (regardless of case), anything else is ignored.
+// If there are no letters the code is empty.
+func Soundex(s string) string {
+	const codeLen = 4
+
+	letters := phoneticLetters(s)
+	if len(letters) == 0 {
+		return ""
+	}
+
+	code := make([]byte, 1, codeLen)
+	code[0] = letters[0]
+	last := soundexCodes[letters[0]-'A']
+
+	for _, l := range letters[1:] {
+		if len(code) == codeLen {
+			break
+		}
+
+		c := soundexCodes[l-'A']
+
+		switch c {
+		case '-':
+			continue
+		case '0':
+		default:
+			if c != last {
+				code = append(code, c)
+			}
+		}
+
+		last = c
+	}
+
+	for len(code) < codeLen {
+		code = append(code, '0')
+	}
+
+	return string(code)
+}
+
+// refinedSoundexCodes gives the Refined Soundex code for each letter A-Z
+const refinedSoundexCodes = "01360240043788015936020505"
+
+// RefinedSoundex returns the Refined Soundex code for the string. This is
+// the first letter followed by digits encoding all the letters (including
+// the first), with adjacent letters having the same code being encoded
+// once. Unlike Soundex, the vowels are encoded (as '0') and the code is not
+// of fixed length. Only the letters A-Z are encoded (regardless of case),
+// anything else is ignored. If there are no letters the code is empty.
+func RefinedSoundex(s string) string {
+	letters := phoneticLetters(s)
+	if len(letters) == 0 {
+		return ""
+	}
+
+	code := make([]byte, 1, len(letters)+1)
+	code[0] = letters[0]
+
+	var last byte
+
+	for _, l := range letters {
+		c := refinedSoundexCodes[l-'A']
+		if c != last {
+			code = append(code, c)
+		}
+
+		last = c
+	}
+
+	return string(code)
+}
+
+// isNYSIISVowel returns true if the letter is a vowel, as used by the
+// NYSIIS algorithm
+func isNYSIISVowel(l byte) bool {
+	return strings.IndexByte("AEIOU", l) >= 0
+}
+
+// NYSIIS returns the New York State Identification and Intelligence System
+// code for the string. The code is at most six letters long. Only the
+// letters A-Z are encoded (regardless of case), anything else is ignored.
+// If there are no letters the code is empty.
+func NYSIIS(s string) string {
+	const maxCodeLen = 6
+
+	letters := phoneticLetters(s)
+	if len(letters) == 0 {
+		return ""
+	}
+
+	name := string(letters)
+
+	// translate the first letters of the name
+	for _, t := range [][2]string{
+		{"MAC", "MCC"},
+		{"KN", "NN"},
+		{"K", "C"},
+		{"PH", "FF"},
+		{"PF", "FF"},
+		{"SCH", "SSS"},
+	} {
+		if strings.HasPrefix(name, t[0]) {
+			name = t[1] + name[len(t[0]):]
+			break
+		}
+	}
+
+	// translate the last letters of the name
+	for _, t := range [][2]string{
+		{"EE", "Y"},
+		{"IE", "Y"},
+		{"DT", "D"},
+		{"RT", "D"},
+		{"RD", "D"},
+		{"NT", "D"},
+		{"ND", "D"},
+	} {
+		if strings.HasSuffix(name, t[0]) {
+			name = name[:len(name)-len(t[0])] + t[1]
+			break
+		}
+	}
+
+	letters = []byte(name)
+	key := []byte{letters[0]}
+
+	for i := 1; i < len(letters); i++ {
+		nysiisTranscode(letters, i)
+
+		if letters[i] != letters[i-1] {
+			key = append(key, letters[i])
+		}
+	}
+
+	if len(key) > 1 && key[len(key)-1] == 'S' {
+		key = key[:len(key)-1]
+	}
+
+	if n := len(key); n > 2 && key[n-2] == 'A' && key[n-1] == 'Y' {
+		key = append(key[:n-2], 'Y')
+	}
+
+	if n := len(key); n > 1 && key[n-1] == 'A' {
+		key = key[:n-1]
+	}
+
+	if len(key) > maxCodeLen {
+		key = key[:maxCodeLen]
+	}
+
+	return string(key)
+}
+
+// nysiisTranscode translates the letter at offset i (which must be > 0)
+// according to the NYSIIS rules. The translation may change the following
+// letters as well.
+func nysiisTranscode(letters []byte, i int) {
+	prev, curr := letters[i-1], letters[i]
+
+	var next, afterNext byte
+	if i+1 < len(letters) {
+		next = letters[i+1]
+	}
+
+	if i+2 < len(letters) {
+		afterNext = letters[i+2]
+	}
+
+	var repl string
+
+	switch {
+	case curr == 'E' && next == 'V':
+		repl = "AF"
+	case isNYSIISVowel(curr):
+		repl = "A"
+	case curr == 'Q':
+		repl = "G"
+	case curr == 'Z':
+		repl = "S"
+	case curr == 'M':
+		repl = "N"
+	case curr == 'K' && next == 'N':
+		repl = "NN"
+	case curr == 'K':
+		repl = "C"
+	case curr == 'S' && next == 'C' && afterNext == 'H':
+		repl = "SSS"
+	case curr == 'P' && next == 'H':
+		repl = "FF"
+	case curr == 'H' && (!isNYSIISVowel(prev) || !isNYSIISVowel(next)):
+		repl = string(prev)
+	case curr == 'W' && isNYSIISVowel(prev):
+		repl = string(prev)
+	default:
+		return
+	}
+
+	copy(letters[i:], repl)
+}
+
+// PhoneticAlgo encapsulates the details needed to provide a phonetic
+// distance. Each string is converted into a phonetic code by the
+// PhoneticEncoder and the codes are compared. If there is no inner Algo
+// then the distance is 0 if the codes are the same and 1 otherwise;
+// otherwise the distance is the inner Algo's distance between the codes.
+type PhoneticAlgo struct {
+	enc   PhoneticEncoder
+	inner Algo
+}
+
+// NewPhoneticAlgo returns a new PhoneticAlgo. If the encoder is nil then a
+// SoundexEncoder is used. The inner Algo may be nil in which case the codes
+// must be identical for the strings to match.
+func NewPhoneticAlgo(enc PhoneticEncoder, inner Algo) *PhoneticAlgo {
+	if enc == nil {
+		enc = SoundexEncoder{}
+	}
+
+	return &PhoneticAlgo{
+		enc:   enc,
+		inner: inner,
+	}
+}
+
+// Name returns the algorithm name. This is the name of the encoder.
+func (a PhoneticAlgo) Name() string {
+	return a.enc.Name()
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a PhoneticAlgo) Desc() string {
+	if a.inner == nil {
+		return ""
+	}
+
+	return "Inner: " + algoDesc(a.inner)
+}
+
+// Dist for a PhoneticAlgo will calculate the distance between the phonetic
+// codes of the two strings
+func (a PhoneticAlgo) Dist(s1, s2 string) float64 {
+	c1, c2 := a.enc.Encode(s1), a.enc.Encode(s2)
+
+	if a.inner != nil {
+		return a.inner.Dist(c1, c2)
+	}
+
+	if c1 == c2 {
+		return 0
+	}
+
+	return 1
+}
+
+// NormalizedDist for a PhoneticAlgo is as for Dist but uses the normalised
+// distance given by the inner Algo
+func (a PhoneticAlgo) NormalizedDist(s1, s2 string) float64 {
+	if a.inner == nil {
+		return a.Dist(s1, s2)
+	}
+
+	return normalizedDistFunc(a.inner)(a.enc.Encode(s1), a.enc.Encode(s2))
+}
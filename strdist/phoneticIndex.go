@@ -0,0 +1,138 @@
+package strdist
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// PhoneticIndex groups a population of strings into buckets by their
+// phonetic codes. Finding strings similar to a target then only needs to
+// consider the strings in the buckets whose codes are the same as (or close
+// to) the code of the target rather than the whole population. These
+// candidate strings are then ranked by a Finder.
+//
+// If the encoder is a MultiPhoneticEncoder then a string is added to the
+// bucket for each of its codes and the buckets for each of the target's
+// codes are searched.
+type PhoneticIndex struct {
+	enc         PhoneticEncoder
+	maxCodeDist int
+	finder      *Finder
+
+	pop     []string
+	buckets map[string][]int
+}
+
+// NewPhoneticIndex returns a new PhoneticIndex holding the population. If
+// the encoder is nil then a SoundexEncoder is used. The maximum code
+// distance gives the largest Levenshtein distance between the codes of the
+// target and of a bucket for the bucket to be searched; it must be >= 0, a
+// value of 0 means that only the buckets having the same code as the target
+// are searched. The Finder is used to rank the candidate strings and must
+// not be nil.
+func NewPhoneticIndex(enc PhoneticEncoder, maxCodeDist int, f *Finder,
+	pop ...string,
+) (*PhoneticIndex, error) {
+	if maxCodeDist < 0 {
+		return nil, fmt.Errorf("the maximum code distance (%d) must be >= 0",
+			maxCodeDist)
+	}
+
+	if f == nil {
+		return nil, errors.New("the Finder must not be nil")
+	}
+
+	if enc == nil {
+		enc = SoundexEncoder{}
+	}
+
+	pi := &PhoneticIndex{
+		enc:         enc,
+		maxCodeDist: maxCodeDist,
+		finder:      f,
+		buckets:     map[string][]int{},
+	}
+	pi.Add(pop...)
+
+	return pi, nil
+}
+
+// NewPhoneticIndexOrPanic returns a new PhoneticIndex. It will panic if the
+// PhoneticIndex cannot be created without errors.
+func NewPhoneticIndexOrPanic(enc PhoneticEncoder, maxCodeDist int,
+	f *Finder, pop ...string,
+) *PhoneticIndex {
+	pi, err := NewPhoneticIndex(enc, maxCodeDist, f, pop...)
+	if err != nil {
+		panic(err)
+	}
+
+	return pi
+}
+
+// Add adds the strings to the population held by the index
+func (pi *PhoneticIndex) Add(pop ...string) {
+	for _, p := range pop {
+		idx := len(pi.pop)
+		pi.pop = append(pi.pop, p)
+
+		for _, code := range phoneticCodes(pi.enc, p) {
+			pi.buckets[code] = append(pi.buckets[code], idx)
+		}
+	}
+}
+
+// Len returns the number of strings in the population held by the index
+func (pi *PhoneticIndex) Len() int {
+	return len(pi.pop)
+}
+
+// Candidates returns the strings in the buckets whose codes are within the
+// maximum code distance of any of the codes for the string. Each string is
+// given once and they are in the order they were added to the index.
+func (pi *PhoneticIndex) Candidates(s string) []string {
+	var idxs []int
+
+	for _, code := range phoneticCodes(pi.enc, s) {
+		if pi.maxCodeDist == 0 {
+			idxs = append(idxs, pi.buckets[code]...)
+			continue
+		}
+
+		for bCode, bIdxs := range pi.buckets {
+			if LevenshteinDistance(code, bCode) <= pi.maxCodeDist {
+				idxs = append(idxs, bIdxs...)
+			}
+		}
+	}
+
+	slices.Sort(idxs)
+	idxs = slices.Compact(idxs)
+
+	candidates := make([]string, 0, len(idxs))
+	for _, i := range idxs {
+		candidates = append(candidates, pi.pop[i])
+	}
+
+	return candidates
+}
+
+// FindLike returns StrDists for those candidate strings (see Candidates)
+// which the Finder finds to be similar to the string (s). They are in the
+// same order as given by the Finder's FindLike method.
+func (pi *PhoneticIndex) FindLike(s string) []StrDist {
+	return pi.finder.FindLike(s, pi.Candidates(s)...)
+}
+
+// FindStrLike returns those candidate strings which are similar to the
+// string (s). Similarity is as for the FindLike func.
+func (pi *PhoneticIndex) FindStrLike(s string) []string {
+	return convertStrDist(pi.FindLike(s))
+}
+
+// FindNStrLike returns the first n candidate strings which are similar to
+// the string (s). Similarity is as for the FindLike func.
+func (pi *PhoneticIndex) FindNStrLike(n int, s string) []string {
+	return convertStrDistN(n, pi.FindLike(s))
+}
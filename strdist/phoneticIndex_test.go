@@ -0,0 +1,125 @@
+package strdist_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewPhoneticIndex(t *testing.T) {
+	f := strdist.DefaultFinders[strdist.AlgoNameLevenshtein]
+
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		maxCodeDist int
+		f           *strdist.Finder
+	}{
+		{
+			ID: testhelper.MkID("good"),
+			f:  f,
+		},
+		{
+			ID:          testhelper.MkID("negative code distance"),
+			maxCodeDist: -1,
+			f:           f,
+			ExpErr: testhelper.MkExpErr(
+				"the maximum code distance (-1) must be >= 0"),
+		},
+		{
+			ID:     testhelper.MkID("nil Finder"),
+			ExpErr: testhelper.MkExpErr("the Finder must not be nil"),
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := strdist.NewPhoneticIndex(nil, tc.maxCodeDist, tc.f, "a")
+		testhelper.CheckExpErr(t, err, tc)
+	}
+}
+
+func TestPhoneticIndex(t *testing.T) {
+	pop := []string{
+		"Smith", "Smyth", "Schmidt", "Smithers", "Jones",
+		"Johns", "Johnson", "Smithson", "Schmitt", "Vasserman",
+	}
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{
+			Threshold:      5,
+			MapToLowerCase: true,
+		},
+		strdist.LevenshteinAlgo{})
+
+	testCases := []struct {
+		testhelper.ID
+		enc           strdist.PhoneticEncoder
+		maxCodeDist   int
+		s             string
+		expCandidates []string
+		expFound      []string
+	}{
+		{
+			ID:            testhelper.MkID("Soundex, same code"),
+			s:             "Smith",
+			expCandidates: []string{"Smith", "Smyth", "Schmidt", "Schmitt"},
+			expFound:      []string{"Smith", "Smyth", "Schmitt", "Schmidt"},
+		},
+		{
+			ID:          testhelper.MkID("Soundex, neighbouring codes"),
+			maxCodeDist: 1,
+			s:           "Smith",
+			expCandidates: []string{
+				"Smith", "Smyth", "Schmidt", "Smithers", "Smithson", "Schmitt",
+			},
+			expFound: []string{
+				"Smith", "Smyth", "Schmitt", "Smithers", "Smithson", "Schmidt",
+			},
+		},
+		{
+			ID:            testhelper.MkID("Soundex, no alternate code"),
+			s:             "Wasserman",
+			expCandidates: []string{},
+			expFound:      []string{},
+		},
+		{
+			ID:            testhelper.MkID("double Metaphone, alternate code"),
+			enc:           strdist.DoubleMetaphoneEncoder{},
+			s:             "Wasserman",
+			expCandidates: []string{"Vasserman"},
+			expFound:      []string{"Vasserman"},
+		},
+		{
+			ID:            testhelper.MkID("double Metaphone, same code"),
+			enc:           strdist.DoubleMetaphoneEncoder{},
+			s:             "Jonas",
+			expCandidates: []string{"Jones", "Johns"},
+			expFound:      []string{"Jones", "Johns"},
+		},
+	}
+
+	for _, tc := range testCases {
+		pi := strdist.NewPhoneticIndexOrPanic(tc.enc, tc.maxCodeDist, f, pop...)
+		testhelper.DiffInt(t, tc.IDStr(), "Len", pi.Len(), len(pop))
+		testhelper.DiffStringSlice(t, tc.IDStr(), "candidates",
+			pi.Candidates(tc.s), tc.expCandidates)
+		testhelper.DiffStringSlice(t, tc.IDStr(), "found",
+			pi.FindStrLike(tc.s), tc.expFound)
+
+		// the results should be the same as from searching the candidates
+		testhelper.DiffSlice(t, tc.IDStr(), "FindLike",
+			pi.FindLike(tc.s), f.FindLike(tc.s, tc.expCandidates...))
+	}
+}
+
+func TestPhoneticIndexAdd(t *testing.T) {
+	f := strdist.DefaultFinders[strdist.CaseBlindAlgoNameLevenshtein]
+	pi := strdist.NewPhoneticIndexOrPanic(nil, 0, f, "Smith")
+	pi.Add("Smyth", "Jones", "Smith")
+
+	testhelper.DiffInt(t, "Add", "Len", pi.Len(), 4)
+	testhelper.DiffStringSlice(t, "Add", "found",
+		pi.FindStrLike("smith"), []string{"Smith", "Smith", "Smyth"})
+	testhelper.DiffStringSlice(t, "Add", "found (max 2)",
+		pi.FindNStrLike(2, "smith"), []string{"Smith", "Smith"})
+}
@@ -0,0 +1,163 @@
+package strdist_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+// phoneticTestCase holds a string and its expected phonetic code
+type phoneticTestCase struct {
+	s       string
+	expCode string
+}
+
+// checkPhoneticCodes checks that the encoder gives the expected codes
+func checkPhoneticCodes(t *testing.T, enc strdist.PhoneticEncoder,
+	testCases []phoneticTestCase,
+) {
+	t.Helper()
+
+	for _, tc := range testCases {
+		testhelper.DiffString(t, enc.Name()+": "+tc.s, "code",
+			enc.Encode(tc.s), tc.expCode)
+	}
+}
+
+func TestSoundex(t *testing.T) {
+	checkPhoneticCodes(t, strdist.SoundexEncoder{}, []phoneticTestCase{
+		{s: "", expCode: ""},
+		{s: "123", expCode: ""},
+		{s: "Robert", expCode: "R163"},
+		{s: "Rupert", expCode: "R163"},
+		{s: "Rubin", expCode: "R150"},
+		{s: "Ashcraft", expCode: "A261"},
+		{s: "Ashcroft", expCode: "A261"},
+		{s: "Tymczak", expCode: "T522"},
+		{s: "Pfister", expCode: "P236"},
+		{s: "Honeyman", expCode: "H555"},
+		{s: "Lee", expCode: "L000"},
+		{s: "O'Hara", expCode: "O600"},
+		{s: "robert", expCode: "R163"},
+	})
+}
+
+func TestRefinedSoundex(t *testing.T) {
+	checkPhoneticCodes(t, strdist.RefinedSoundexEncoder{}, []phoneticTestCase{
+		{s: "", expCode: ""},
+		{s: "testing", expCode: "T6036084"},
+		{s: "TESTING", expCode: "T6036084"},
+		{s: "The", expCode: "T60"},
+		{s: "quick", expCode: "Q503"},
+		{s: "brown", expCode: "B1908"},
+		{s: "fox", expCode: "F205"},
+		{s: "jumped", expCode: "J408106"},
+		{s: "lazy", expCode: "L7050"},
+		{s: "dogs", expCode: "D6043"},
+	})
+}
+
+func TestNYSIIS(t *testing.T) {
+	checkPhoneticCodes(t, strdist.NYSIISEncoder{}, []phoneticTestCase{
+		{s: "", expCode: ""},
+		{s: "Bishop", expCode: "BASAP"},
+		{s: "Carlson", expCode: "CARLSA"},
+		{s: "MACINTOSH", expCode: "MCANT"},
+		{s: "KNUTH", expCode: "NAT"},
+		{s: "KOEHN", expCode: "CAN"},
+		{s: "PHILLIPSON", expCode: "FALAPS"},
+		{s: "PFEISTER", expCode: "FASTAR"},
+		{s: "SCHOENHOEFT", expCode: "SANAFT"},
+		{s: "MCKEE", expCode: "MCY"},
+		{s: "MACKIE", expCode: "MCY"},
+		{s: "HEITSCHMIDT", expCode: "HATSNA"},
+		{s: "BART", expCode: "BAD"},
+		{s: "HURD", expCode: "HAD"},
+		{s: "HUNT", expCode: "HAD"},
+		{s: "WESTERLUND", expCode: "WASTAR"},
+		{s: "CASSTEVENS", expCode: "CASTAF"},
+		{s: "VASQUEZ", expCode: "VASG"},
+		{s: "FRAZIER", expCode: "FRASAR"},
+		{s: "BOWMAN", expCode: "BANAN"},
+		{s: "MCKNIGHT", expCode: "MCNAGT"},
+		{s: "RICKERT", expCode: "RACAD"},
+		{s: "DEUTSCH", expCode: "DAT"},
+		{s: "WESTPHAL", expCode: "WASTFA"},
+		{s: "SHRIVER", expCode: "SRAVAR"},
+		{s: "KUHL", expCode: "CAL"},
+		{s: "RAWSON", expCode: "RASAN"},
+		{s: "JILES", expCode: "JAL"},
+		{s: "CARRAWAY", expCode: "CARY"},
+		{s: "YAMADA", expCode: "YANAD"},
+	})
+}
+
+func TestPhoneticAlgo(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		algo    *strdist.PhoneticAlgo
+		a, b    string
+		expDist float64
+		expName string
+		expDesc string
+	}{
+		{
+			ID:      testhelper.MkID("default encoder, same code"),
+			algo:    strdist.NewPhoneticAlgo(nil, nil),
+			a:       "Smith",
+			b:       "Smyth",
+			expDist: 0,
+			expName: strdist.AlgoNameSoundex,
+		},
+		{
+			ID:      testhelper.MkID("default encoder, different code"),
+			algo:    strdist.NewPhoneticAlgo(nil, nil),
+			a:       "Smith",
+			b:       "Smithers",
+			expDist: 1,
+			expName: strdist.AlgoNameSoundex,
+		},
+		{
+			ID: testhelper.MkID("inner algo"),
+			algo: strdist.NewPhoneticAlgo(strdist.SoundexEncoder{},
+				strdist.LevenshteinAlgo{}),
+			a:       "Smith",
+			b:       "Smithers",
+			expDist: 1,
+			expName: strdist.AlgoNameSoundex,
+			expDesc: "Inner: " + strdist.AlgoNameLevenshtein,
+		},
+		{
+			ID: testhelper.MkID("NYSIIS, inner algo"),
+			algo: strdist.NewPhoneticAlgo(strdist.NYSIISEncoder{},
+				strdist.LevenshteinAlgo{}),
+			a:       "Bishop",
+			b:       "Carlson",
+			expDist: 4,
+			expName: strdist.AlgoNameNYSIIS,
+			expDesc: "Inner: " + strdist.AlgoNameLevenshtein,
+		},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffFloat(t, tc.IDStr(), "Dist",
+			tc.algo.Dist(tc.a, tc.b), tc.expDist, 0)
+		testhelper.DiffString(t, tc.IDStr(), "Name",
+			tc.algo.Name(), tc.expName)
+		testhelper.DiffString(t, tc.IDStr(), "Desc",
+			tc.algo.Desc(), tc.expDesc)
+	}
+}
+
+func TestPhoneticFinder(t *testing.T) {
+	pop := []string{"Smithers", "Jones", "Schmidt", "Smyth"}
+
+	f := strdist.DefaultFinders[strdist.CaseBlindAlgoNameSoundex]
+	finderChecker(t, "Soundex", "default finder",
+		"Smith", pop, f, []string{"Smyth", "Schmidt"})
+
+	f = strdist.DefaultFinders[strdist.CaseBlindAlgoNameNYSIIS]
+	finderChecker(t, "NYSIIS", "default finder",
+		"Shmidt", pop, f, []string{"Schmidt"})
+}
@@ -0,0 +1,223 @@
+package strdist
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RatcliffObershelpAlgo encapsulates the details needed to provide the
+// Ratcliff/Obershelp distance (also known as Gestalt Pattern Matching). The
+// similarity is calculated as for the ratio of Python's
+// difflib.SequenceMatcher and the distance is 1 minus this.
+type RatcliffObershelpAlgo struct {
+	// AutoJunk is set to indicate that the automatic junk heuristic should
+	// be applied. If the second string is at least 200 runes long then any
+	// rune which appears in it more than 1% of the time is treated as
+	// popular and is not used to start a match. This is the same heuristic
+	// as Python's difflib.SequenceMatcher applies by default.
+	AutoJunk bool
+}
+
+// Name returns the algorithm name
+func (RatcliffObershelpAlgo) Name() string {
+	return AlgoNameRatcliffObershelp
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a RatcliffObershelpAlgo) Desc() string {
+	return fmt.Sprintf("AutoJunk: %-5.5v", a.AutoJunk)
+}
+
+// Dist for a RatcliffObershelpAlgo will calculate the Ratcliff/Obershelp
+// distance between the two strings
+func (a RatcliffObershelpAlgo) Dist(s1, s2 string) float64 {
+	return 1.0 - RatcliffObershelpSimilarity(s1, s2, a.AutoJunk)
+}
+
+// NormalizedDist for a RatcliffObershelpAlgo is the same as Dist
+func (a RatcliffObershelpAlgo) NormalizedDist(s1, s2 string) float64 {
+	return a.Dist(s1, s2)
+}
+
+// MatchingBlock describes a matching sub-sequence of two strings. The
+// matching runes start at offset A in the first string and at offset B in
+// the second and are Size runes long. The offsets are rune offsets not byte
+// offsets.
+type MatchingBlock struct {
+	A    int
+	B    int
+	Size int
+}
+
+// RatcliffObershelpSimilarity returns the Ratcliff/Obershelp similarity of
+// strings a and b. This is twice the number of matching runes divided by the
+// total number of runes in the two strings and so is between 0 and 1. Two
+// zero-length strings are taken as identical (with a similarity of 1). The
+// autoJunk parameter controls the use of the automatic junk heuristic as for
+// the RatcliffObershelpAlgo.
+func RatcliffObershelpSimilarity(a, b string, autoJunk bool) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	totLen := len(ra) + len(rb)
+	if totLen == 0 {
+		return 1.0
+	}
+
+	matches := 0
+	for _, mb := range matchingBlocks(ra, rb, autoJunk) {
+		matches += mb.Size
+	}
+
+	return 2.0 * float64(matches) / float64(totLen)
+}
+
+// MatchingBlocks returns the blocks of runes which match between strings a
+// and b as found by the Ratcliff/Obershelp algorithm. The blocks are in
+// increasing order of their offsets and adjacent blocks are merged. Note
+// that, unlike Python's difflib, there is no final zero-length block. The
+// autoJunk parameter controls the use of the automatic junk heuristic as for
+// the RatcliffObershelpAlgo.
+func MatchingBlocks(a, b string, autoJunk bool) []MatchingBlock {
+	return matchingBlocks([]rune(a), []rune(b), autoJunk)
+}
+
+// roMatcher holds the details needed to find the longest matching blocks of
+// runes between two rune slices
+type roMatcher struct {
+	a, b []rune
+	// b2j maps each rune in b to the (increasing) offsets at which it
+	// appears. Popular runes are removed if the auto junk heuristic is used.
+	b2j map[rune][]int
+}
+
+// newROMatcher returns a roMatcher for the two rune slices with the b2j map
+// populated.
+func newROMatcher(a, b []rune, autoJunk bool) *roMatcher {
+	m := &roMatcher{
+		a:   a,
+		b:   b,
+		b2j: map[rune][]int{},
+	}
+
+	for j, r := range b {
+		m.b2j[r] = append(m.b2j[r], j)
+	}
+
+	const autoJunkMinLen = 200
+	if autoJunk && len(b) >= autoJunkMinLen {
+		popularCount := len(b)/100 + 1
+		for r, idxs := range m.b2j {
+			if len(idxs) > popularCount {
+				delete(m.b2j, r)
+			}
+		}
+	}
+
+	return m
+}
+
+// findLongestMatch returns the longest matching block in a[aLo:aHi] and
+// b[bLo:bHi]. If there are several longest blocks then the one starting
+// earliest in a is returned and of those the one starting earliest in b.
+func (m *roMatcher) findLongestMatch(aLo, aHi, bLo, bHi int) MatchingBlock {
+	best := MatchingBlock{A: aLo, B: bLo}
+
+	// j2len[j] holds the length of the match ending with a[i-1] and b[j]
+	j2len := map[int]int{}
+
+	for i := aLo; i < aHi; i++ {
+		newJ2len := map[int]int{}
+
+		for _, j := range m.b2j[m.a[i]] {
+			if j < bLo {
+				continue
+			}
+
+			if j >= bHi {
+				break
+			}
+
+			k := j2len[j-1] + 1
+			newJ2len[j] = k
+
+			if k > best.Size {
+				best = MatchingBlock{A: i - k + 1, B: j - k + 1, Size: k}
+			}
+		}
+
+		j2len = newJ2len
+	}
+
+	// extend the match with any adjacent matching runes; these will be
+	// runes which were removed from b2j as popular
+	for best.A > aLo && best.B > bLo &&
+		m.a[best.A-1] == m.b[best.B-1] {
+		best.A--
+		best.B--
+		best.Size++
+	}
+
+	for best.A+best.Size < aHi && best.B+best.Size < bHi &&
+		m.a[best.A+best.Size] == m.b[best.B+best.Size] {
+		best.Size++
+	}
+
+	return best
+}
+
+// matchingBlocks returns the matching blocks for the rune slices a and b.
+func matchingBlocks(a, b []rune, autoJunk bool) []MatchingBlock {
+	m := newROMatcher(a, b, autoJunk)
+
+	type span struct {
+		aLo, aHi, bLo, bHi int
+	}
+
+	queue := []span{{0, len(a), 0, len(b)}}
+	blocks := []MatchingBlock{}
+
+	for len(queue) > 0 {
+		s := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		mb := m.findLongestMatch(s.aLo, s.aHi, s.bLo, s.bHi)
+		if mb.Size == 0 {
+			continue
+		}
+
+		blocks = append(blocks, mb)
+
+		if s.aLo < mb.A && s.bLo < mb.B {
+			queue = append(queue, span{s.aLo, mb.A, s.bLo, mb.B})
+		}
+
+		if mb.A+mb.Size < s.aHi && mb.B+mb.Size < s.bHi {
+			queue = append(queue,
+				span{mb.A + mb.Size, s.aHi, mb.B + mb.Size, s.bHi})
+		}
+	}
+
+	slices.SortFunc(blocks, func(mb1, mb2 MatchingBlock) int {
+		if mb1.A != mb2.A {
+			return mb1.A - mb2.A
+		}
+
+		return mb1.B - mb2.B
+	})
+
+	// merge adjacent blocks
+	merged := make([]MatchingBlock, 0, len(blocks))
+
+	for _, mb := range blocks {
+		if n := len(merged); n > 0 &&
+			merged[n-1].A+merged[n-1].Size == mb.A &&
+			merged[n-1].B+merged[n-1].Size == mb.B {
+			merged[n-1].Size += mb.Size
+			continue
+		}
+
+		merged = append(merged, mb)
+	}
+
+	return merged
+}
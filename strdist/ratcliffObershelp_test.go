@@ -0,0 +1,128 @@
+package strdist_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+// TestRatcliffObershelp checks the Ratcliff/Obershelp similarity and
+// matching blocks. The expected values are as given by Python's
+// difflib.SequenceMatcher
+func TestRatcliffObershelp(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b      string
+		autoJunk  bool
+		expSim    float64
+		expBlocks []strdist.MatchingBlock
+	}{
+		{
+			ID:        testhelper.MkID("both empty"),
+			expSim:    1.0,
+			expBlocks: []strdist.MatchingBlock{},
+		},
+		{
+			ID:        testhelper.MkID("one empty"),
+			a:         "abc",
+			expSim:    0.0,
+			expBlocks: []strdist.MatchingBlock{},
+		},
+		{
+			ID:     testhelper.MkID("offset"),
+			a:      "abcd",
+			b:      "bcde",
+			expSim: 0.75,
+			expBlocks: []strdist.MatchingBlock{
+				{A: 1, B: 0, Size: 3},
+			},
+		},
+		{
+			ID:     testhelper.MkID("WIKIMEDIA"),
+			a:      "WIKIMEDIA",
+			b:      "WIKIMANIA",
+			expSim: 0.7777777777777778,
+			expBlocks: []strdist.MatchingBlock{
+				{A: 0, B: 0, Size: 5},
+				{A: 7, B: 7, Size: 2},
+			},
+		},
+		{
+			ID:     testhelper.MkID("GESTALT"),
+			a:      "GESTALT PATTERN MATCHING",
+			b:      "GESTALT PRACTICE",
+			expSim: 0.6,
+			expBlocks: []strdist.MatchingBlock{
+				{A: 0, B: 0, Size: 9},
+				{A: 9, B: 10, Size: 1},
+				{A: 10, B: 12, Size: 1},
+				{A: 12, B: 15, Size: 1},
+			},
+		},
+		{
+			ID:     testhelper.MkID("inserted word"),
+			a:      "private Thread currentThread;",
+			b:      "private volatile Thread currentThread;",
+			expSim: 0.8656716417910447,
+			expBlocks: []strdist.MatchingBlock{
+				{A: 0, B: 0, Size: 6},
+				{A: 6, B: 15, Size: 23},
+			},
+		},
+		{
+			ID:     testhelper.MkID("multi-byte runes"),
+			a:      "§¶ab",
+			b:      "x§¶",
+			expSim: 0.5714285714285714,
+			expBlocks: []strdist.MatchingBlock{
+				{A: 0, B: 1, Size: 2},
+			},
+		},
+		{
+			ID:     testhelper.MkID("long, no auto-junk"),
+			a:      strings.Repeat("ab", 150) + "xyz",
+			b:      strings.Repeat("ba", 150) + "xyz",
+			expSim: 0.9966996699669967,
+			expBlocks: []strdist.MatchingBlock{
+				{A: 0, B: 1, Size: 299},
+				{A: 300, B: 300, Size: 3},
+			},
+		},
+		{
+			ID:       testhelper.MkID("long, auto-junk"),
+			a:        strings.Repeat("ab", 150) + "xyz",
+			b:        strings.Repeat("ba", 150) + "xyz",
+			autoJunk: true,
+			expSim:   0.009900990099009901,
+			expBlocks: []strdist.MatchingBlock{
+				{A: 300, B: 300, Size: 3},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		const epsilon = 0.0000001
+
+		sim := strdist.RatcliffObershelpSimilarity(tc.a, tc.b, tc.autoJunk)
+		testhelper.DiffFloat(t, tc.IDStr(), "similarity",
+			sim, tc.expSim, epsilon)
+
+		a := strdist.RatcliffObershelpAlgo{AutoJunk: tc.autoJunk}
+		testhelper.DiffFloat(t, tc.IDStr(), "distance",
+			a.Dist(tc.a, tc.b), 1.0-tc.expSim, epsilon)
+
+		blocks := strdist.MatchingBlocks(tc.a, tc.b, tc.autoJunk)
+		testhelper.DiffSlice(t, tc.IDStr(), "matching blocks",
+			blocks, tc.expBlocks)
+	}
+}
+
+func TestRatcliffObershelpFinder(t *testing.T) {
+	f := strdist.DefaultFinders[strdist.AlgoNameRatcliffObershelp]
+	pop := []string{"ape", "apple", "peach", "puppy"}
+	// this is the example given for Python's difflib.get_close_matches
+	finderChecker(t, "Ratcliff/Obershelp", "default finder",
+		"appel", pop, f, []string{"apple", "ape"})
+}
@@ -1,30 +1,134 @@
 package strdist
 
 import (
-	"math"
-	"unicode/utf8"
+	"errors"
+	"fmt"
+)
+
+// LevNorm specifies how the Levenshtein distance is normalised (scaled) to
+// give the scaled Levenshtein distance
+type LevNorm int
 
-	"github.com/nickwells/mathutil.mod/v2/mathutil"
+// These are the available normalisations. Each gives a value between 0 and
+// 1 with identical strings having a zero distance.
+//
+// LevNormMaxLen divides the distance by the length of the longer string.
+//
+// LevNormSumLen divides the distance by the sum of the lengths of the two
+// strings. This only reaches 1 if one of the strings is empty.
+//
+// LevNormAlignLen divides the distance by the length of the longest
+// alignment having that distance. This is the number of matches,
+// substitutions, insertions and deletions needed to transform one string
+// into the other.
+//
+// LevNormYujianBo gives the normalised Levenshtein distance described by Li
+// Yujian and Liu Bo, 2d/(len(a)+len(b)+d) where d is the Levenshtein
+// distance. Unlike the other normalisations this preserves the triangle
+// inequality and so gives a true distance metric.
+const (
+	LevNormMaxLen LevNorm = iota
+	LevNormSumLen
+	LevNormAlignLen
+	LevNormYujianBo
 )
 
+// String returns a string describing the normalisation
+func (norm LevNorm) String() string {
+	switch norm {
+	case LevNormMaxLen:
+		return "max length"
+	case LevNormSumLen:
+		return "sum of lengths"
+	case LevNormAlignLen:
+		return "alignment length"
+	case LevNormYujianBo:
+		return "Yujian-Bo"
+	}
+
+	return fmt.Sprintf("LevNorm(%d)", int(norm))
+}
+
+// Check returns a non-nil error if the normalisation is not one of the
+// available values
+func (norm LevNorm) Check() error {
+	if norm < LevNormMaxLen || norm > LevNormYujianBo {
+		return errors.New("unknown Levenshtein normalisation: " + norm.String())
+	}
+
+	return nil
+}
+
+// scale returns the Levenshtein distance d, normalised as given by the
+// LevNorm. The aLen and bLen values give the lengths of the two strings
+// and alignLen the length of the alignment. At least one of the lengths
+// must be non-zero. It will panic if the LevNorm is invalid.
+func (norm LevNorm) scale(d, aLen, bLen, alignLen int) float64 {
+	switch norm {
+	case LevNormMaxLen:
+		return float64(d) / float64(max(aLen, bLen))
+	case LevNormSumLen:
+		return float64(d) / float64(aLen+bLen)
+	case LevNormAlignLen:
+		return float64(d) / float64(alignLen)
+	case LevNormYujianBo:
+		return 2.0 * float64(d) / float64(aLen+bLen+d)
+	}
+
+	panic(norm.Check())
+}
+
 // ScaledLevAlgo encapsulates the details needed to provide the ScaledLev
-// distance.
-type ScaledLevAlgo struct{}
+// distance. The zero value normalises the distance by the length of the
+// longer string.
+type ScaledLevAlgo struct {
+	norm LevNorm
+}
+
+// NewScaledLevAlgo returns a new ScaledLevAlgo with the normalisation set
+func NewScaledLevAlgo(norm LevNorm) (*ScaledLevAlgo, error) {
+	if err := norm.Check(); err != nil {
+		return nil, err
+	}
+
+	return &ScaledLevAlgo{norm: norm}, nil
+}
+
+// NewScaledLevAlgoOrPanic returns a new ScaledLevAlgo. It will panic if the
+// algo cannot be created without errors.
+func NewScaledLevAlgoOrPanic(norm LevNorm) *ScaledLevAlgo {
+	a, err := NewScaledLevAlgo(norm)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
 
 // Name returns the algorithm name
 func (ScaledLevAlgo) Name() string {
 	return AlgoNameScaledLevenshtein
 }
 
-// Desc returns a string describing the algorithm configuration
-func (ScaledLevAlgo) Desc() string {
-	return ""
+// Desc returns a string describing the algorithm configuration. This is
+// empty if the default normalisation (LevNormMaxLen) is used.
+func (a ScaledLevAlgo) Desc() string {
+	if a.norm == LevNormMaxLen {
+		return ""
+	}
+
+	return "Norm: " + a.norm.String()
 }
 
 // Dist for a ScaledLevAlgo will calculate the ScaledLev distance between
 // the two strings
-func (ScaledLevAlgo) Dist(s1, s2 string) float64 {
-	return ScaledLevDistance(s1, s2)
+func (a ScaledLevAlgo) Dist(s1, s2 string) float64 {
+	return ScaledLevDistanceNorm(s1, s2, a.norm)
+}
+
+// NormalizedDist for a ScaledLevAlgo is the same as Dist
+func (a ScaledLevAlgo) NormalizedDist(s1, s2 string) float64 {
+	return a.Dist(s1, s2)
 }
 
 // ScaledLevDistance calculates the Scaled Levenshtein distance between
@@ -32,37 +136,21 @@ func (ScaledLevAlgo) Dist(s1, s2 string) float64 {
 // the lengths of the two strings. Two zero-length strings are taken as
 // identical (with a zero distance between them)
 func ScaledLevDistance(a, b string) float64 {
-	aLen := utf8.RuneCountInString(a)
-	bLen := utf8.RuneCountInString(b)
-
-	if aLen == 0 && bLen == 0 {
-		return 0.0
-	}
+	return ScaledLevDistanceNorm(a, b, LevNormMaxLen)
+}
 
-	d := make([][]int, aLen+1)
-	for i := range d {
-		d[i] = make([]int, bLen+1)
-		d[i][0] = i
-	}
+// ScaledLevDistanceNorm calculates the Scaled Levenshtein distance between
+// strings a and b using the given normalisation. Two zero-length strings
+// are taken as identical (with a zero distance between them). It will panic
+// if the LevNorm is invalid.
+func ScaledLevDistanceNorm(a, b string, norm LevNorm) float64 {
+	ra, rb := []rune(a), []rune(b)
 
-	for i := 1; i <= bLen; i++ {
-		d[0][i] = i
+	if len(ra) == 0 && len(rb) == 0 {
+		return 0.0
 	}
 
-	for j, bRune := range b {
-		for i, aRune := range a {
-			var subsCost int
-			if aRune != bRune {
-				subsCost = 1
-			}
-
-			del := d[i][j+1] + 1
-			ins := d[i+1][j] + 1
-			sub := d[i][j] + subsCost
-
-			d[i+1][j+1] = mathutil.MinOfInt(del, ins, sub)
-		}
-	}
+	d, alignLen := levenshtein(ra, rb)
 
-	return float64(d[aLen][bLen]) / math.Max(float64(aLen), float64(bLen))
+	return norm.scale(d, len(ra), len(rb), alignLen)
 }
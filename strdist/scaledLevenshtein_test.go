@@ -0,0 +1,140 @@
+package strdist_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewScaledLevAlgo(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		norm    strdist.LevNorm
+		expDesc string
+	}{
+		{
+			ID:      testhelper.MkID("max length"),
+			norm:    strdist.LevNormMaxLen,
+			expDesc: "",
+		},
+		{
+			ID:      testhelper.MkID("Yujian-Bo"),
+			norm:    strdist.LevNormYujianBo,
+			expDesc: "Norm: Yujian-Bo",
+		},
+		{
+			ID:   testhelper.MkID("bad norm"),
+			norm: strdist.LevNorm(99),
+			ExpErr: testhelper.MkExpErr(
+				"unknown Levenshtein normalisation: LevNorm(99)"),
+		},
+	}
+
+	for _, tc := range testCases {
+		a, err := strdist.NewScaledLevAlgo(tc.norm)
+		if testhelper.CheckExpErr(t, err, tc) &&
+			err == nil {
+			testhelper.DiffString(t, tc.IDStr(), "Desc", a.Desc(), tc.expDesc)
+		}
+	}
+
+	testhelper.DiffString(t, "zero value", "Desc",
+		strdist.ScaledLevAlgo{}.Desc(), "")
+}
+
+func TestScaledLevDistanceNorm(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b        string
+		expMaxLen   float64
+		expSumLen   float64
+		expAlignLen float64
+		expYujianBo float64
+	}{
+		{
+			ID: testhelper.MkID("both empty"),
+		},
+		{
+			ID:          testhelper.MkID("one empty"),
+			a:           "abc",
+			expMaxLen:   1,
+			expSumLen:   1,
+			expAlignLen: 1,
+			expYujianBo: 1,
+		},
+		{
+			ID:          testhelper.MkID("identical"),
+			a:           "abc",
+			b:           "abc",
+			expMaxLen:   0,
+			expSumLen:   0,
+			expAlignLen: 0,
+			expYujianBo: 0,
+		},
+		{
+			ID:          testhelper.MkID("swapped"),
+			a:           "ab",
+			b:           "ba",
+			expMaxLen:   1,
+			expSumLen:   2.0 / 4.0,
+			expAlignLen: 2.0 / 3.0,
+			expYujianBo: 4.0 / 6.0,
+		},
+		{
+			ID:          testhelper.MkID("Kitten/Sitting"),
+			a:           "Kitten",
+			b:           "Sitting",
+			expMaxLen:   3.0 / 7.0,
+			expSumLen:   3.0 / 13.0,
+			expAlignLen: 3.0 / 7.0,
+			expYujianBo: 6.0 / 16.0,
+		},
+		{
+			ID:          testhelper.MkID("Saturday/Sunday"),
+			a:           "Saturday",
+			b:           "Sunday",
+			expMaxLen:   3.0 / 8.0,
+			expSumLen:   3.0 / 14.0,
+			expAlignLen: 3.0 / 8.0,
+			expYujianBo: 6.0 / 17.0,
+		},
+		{
+			ID:          testhelper.MkID("multi-byte"),
+			a:           "a§¶b",
+			b:           "a¶b",
+			expMaxLen:   1.0 / 4.0,
+			expSumLen:   1.0 / 7.0,
+			expAlignLen: 1.0 / 4.0,
+			expYujianBo: 2.0 / 8.0,
+		},
+	}
+
+	const epsilon = 1e-12
+
+	for _, tc := range testCases {
+		for _, norm := range []struct {
+			norm    strdist.LevNorm
+			expDist float64
+		}{
+			{strdist.LevNormMaxLen, tc.expMaxLen},
+			{strdist.LevNormSumLen, tc.expSumLen},
+			{strdist.LevNormAlignLen, tc.expAlignLen},
+			{strdist.LevNormYujianBo, tc.expYujianBo},
+		} {
+			a := strdist.NewScaledLevAlgoOrPanic(norm.norm)
+
+			testhelper.DiffFloat(t, tc.IDStr(),
+				fmt.Sprintf("%s: Dist(%q, %q)", norm.norm, tc.a, tc.b),
+				a.Dist(tc.a, tc.b), norm.expDist, epsilon)
+			testhelper.DiffFloat(t, tc.IDStr(),
+				fmt.Sprintf("%s: Dist(%q, %q)", norm.norm, tc.b, tc.a),
+				a.Dist(tc.b, tc.a), norm.expDist, epsilon)
+		}
+
+		testhelper.DiffFloat(t, tc.IDStr(), "ScaledLevDistance",
+			strdist.ScaledLevDistance(tc.a, tc.b), tc.expMaxLen, epsilon)
+	}
+}
@@ -0,0 +1,98 @@
+package strdist
+
+// SmithWatermanAlgo encapsulates the details needed to provide the
+// Smith-Waterman distance. This finds the best local alignment of the two
+// strings, the pair of substrings which align with the highest score, and so
+// it is suited to finding a short string appearing, approximately, within a
+// longer one. The scores are given by the AlignScores and affine gap
+// penalties (Gotoh's algorithm) are supported.
+//
+// The distance is 1 minus the score of the best local alignment divided by
+// the score of the shorter string aligned with itself. It is between 0 and
+// 1 and is zero if the shorter string appears unchanged in the longer.
+type SmithWatermanAlgo struct {
+	sc AlignScores
+}
+
+// NewSmithWatermanAlgo returns a new SmithWatermanAlgo with the scores set.
+// The scores must be valid.
+func NewSmithWatermanAlgo(sc AlignScores) (*SmithWatermanAlgo, error) {
+	if err := sc.Check(); err != nil {
+		return nil, err
+	}
+
+	return &SmithWatermanAlgo{sc: sc}, nil
+}
+
+// NewSmithWatermanAlgoOrPanic returns a new SmithWatermanAlgo. It will panic
+// if the algo cannot be created without errors.
+func NewSmithWatermanAlgoOrPanic(sc AlignScores) *SmithWatermanAlgo {
+	a, err := NewSmithWatermanAlgo(sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// Name returns the algorithm name
+func (SmithWatermanAlgo) Name() string {
+	return AlgoNameSmithWaterman
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a SmithWatermanAlgo) Desc() string {
+	return a.sc.Desc()
+}
+
+// Dist for a SmithWatermanAlgo will calculate the Smith-Waterman distance
+// between the two strings
+func (a SmithWatermanAlgo) Dist(s1, s2 string) float64 {
+	ra, rb := []rune(s1), []rune(s2)
+
+	return localDist(ra, rb, a.sc, gotohScore(ra, rb, a.sc, true))
+}
+
+// NormalizedDist for a SmithWatermanAlgo is the same as Dist
+func (a SmithWatermanAlgo) NormalizedDist(s1, s2 string) float64 {
+	return a.Dist(s1, s2)
+}
+
+// Alignment returns the best local alignment of the two strings. See
+// SmithWatermanAlignment for details.
+func (a SmithWatermanAlgo) Alignment(s1, s2 string) LocalAlignment {
+	return SmithWatermanAlignment(s1, s2, a.sc)
+}
+
+// LocalAlignment records the best local alignment of two strings. The
+// Edits of the Alignment cover only the aligned substrings, running from
+// AStart to AEnd in the first string and from BStart to BEnd in the second.
+// These are rune offsets (not byte offsets) and the ends are exclusive. The
+// Dist is the normalised distance as given by the SmithWatermanAlgo.
+type LocalAlignment struct {
+	ScoredAlignment
+
+	AStart, AEnd int
+	BStart, BEnd int
+}
+
+// SmithWatermanAlignment returns the best local alignment of strings a and
+// b using the scores given. If there are several alignments with the best
+// score then the one ending earliest is returned. If no runes match then
+// the alignment will have no Edits and a zero Score. The scores are not
+// checked and should be valid.
+func SmithWatermanAlignment(a, b string, sc AlignScores) LocalAlignment {
+	ra, rb := []rune(a), []rune(b)
+	t := newGotohTable(ra, rb, sc, true)
+
+	la := LocalAlignment{
+		ScoredAlignment: ScoredAlignment{
+			Alignment: Alignment{Dist: localDist(ra, rb, sc, t.bestScore)},
+			Score:     t.bestScore,
+		},
+	}
+	la.Edits, la.AStart, la.BStart = t.traceback()
+	la.AEnd, la.BEnd = t.bestI, t.bestJ
+
+	return la
+}
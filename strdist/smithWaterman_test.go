@@ -0,0 +1,241 @@
+package strdist_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestNewSmithWatermanAlgo(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		sc      strdist.AlignScores
+		expDesc string
+	}{
+		{
+			ID:      testhelper.MkID("default scores"),
+			sc:      strdist.DfltAlignScores,
+			expDesc: "Match: 2 Mismatch: -1 GapOpen: 2 GapExtend: 1",
+		},
+		{
+			ID:     testhelper.MkID("bad Match score"),
+			sc:     strdist.AlignScores{Match: 0, Mismatch: -1},
+			ExpErr: testhelper.MkExpErr("the Match score (0) must be > 0"),
+		},
+		{
+			ID: testhelper.MkID("bad Mismatch score"),
+			sc: strdist.AlignScores{Match: 1, Mismatch: 1},
+			ExpErr: testhelper.MkExpErr(
+				"the Mismatch score (1) must be < the Match score (1)"),
+		},
+		{
+			ID: testhelper.MkID("bad GapOpen penalty"),
+			sc: strdist.AlignScores{Match: 1, Mismatch: -1, GapOpen: -1},
+			ExpErr: testhelper.MkExpErr(
+				"the GapOpen cost (-1.000000) must be >= 0"),
+		},
+		{
+			ID: testhelper.MkID("bad GapExtend penalty"),
+			sc: strdist.AlignScores{Match: 1, Mismatch: -1, GapExtend: -2},
+			ExpErr: testhelper.MkExpErr(
+				"the GapExtend cost (-2.000000) must be >= 0"),
+		},
+	}
+
+	for _, tc := range testCases {
+		a, err := strdist.NewSmithWatermanAlgo(tc.sc)
+		if testhelper.CheckExpErr(t, err, tc) &&
+			err == nil {
+			testhelper.DiffString(t, tc.IDStr(), "Name",
+				a.Name(), strdist.AlgoNameSmithWaterman)
+			testhelper.DiffString(t, tc.IDStr(), "Desc", a.Desc(), tc.expDesc)
+		}
+	}
+}
+
+func TestSmithWaterman(t *testing.T) {
+	linearGaps := strdist.AlignScores{
+		Match:     2,
+		Mismatch:  -1,
+		GapOpen:   2,
+		GapExtend: 2,
+	}
+
+	testCases := []struct {
+		testhelper.ID
+		sc        strdist.AlignScores
+		a, b      string
+		expDist   float64
+		expScore  float64
+		expMarkup string
+		expAStart int
+		expAEnd   int
+		expBStart int
+		expBEnd   int
+	}{
+		{
+			ID:        testhelper.MkID("both empty"),
+			sc:        strdist.DfltAlignScores,
+			expDist:   0,
+			expScore:  0,
+			expMarkup: "",
+		},
+		{
+			ID:        testhelper.MkID("nothing matches"),
+			sc:        strdist.DfltAlignScores,
+			a:         "abc",
+			b:         "xyz",
+			expDist:   1,
+			expScore:  0,
+			expMarkup: "",
+		},
+		{
+			ID:        testhelper.MkID("exact substring"),
+			sc:        strdist.DfltAlignScores,
+			a:         "brown fox",
+			b:         "the quick brown fox jumps",
+			expDist:   0,
+			expScore:  18,
+			expMarkup: "brown fox",
+			expAStart: 0,
+			expAEnd:   9,
+			expBStart: 10,
+			expBEnd:   19,
+		},
+		{
+			ID:        testhelper.MkID("approximate substring"),
+			sc:        strdist.DfltAlignScores,
+			a:         "brwn fox",
+			b:         "the quick brown fox jumps",
+			expDist:   1 - 14.0/16.0,
+			expScore:  14,
+			expMarkup: "br[+o]wn fox",
+			expAStart: 0,
+			expAEnd:   8,
+			expBStart: 10,
+			expBEnd:   19,
+		},
+		{
+			ID:        testhelper.MkID("affine gap"),
+			sc:        strdist.DfltAlignScores,
+			a:         "abcdef",
+			b:         "abcXYZdef",
+			expDist:   1 - 8.0/12.0,
+			expScore:  8,
+			expMarkup: "abc[+XYZ]def",
+			expAStart: 0,
+			expAEnd:   6,
+			expBStart: 0,
+			expBEnd:   9,
+		},
+		{
+			ID:        testhelper.MkID("linear gap"),
+			sc:        linearGaps,
+			a:         "abcdef",
+			b:         "abcXYZdef",
+			expDist:   1 - 6.0/12.0,
+			expScore:  6,
+			expMarkup: "abc",
+			expAStart: 0,
+			expAEnd:   3,
+			expBStart: 0,
+			expBEnd:   3,
+		},
+		{
+			ID:        testhelper.MkID("multi-byte"),
+			sc:        strdist.DfltAlignScores,
+			a:         "§¶",
+			b:         "ab§¶cd",
+			expDist:   0,
+			expScore:  4,
+			expMarkup: "§¶",
+			expAStart: 0,
+			expAEnd:   2,
+			expBStart: 2,
+			expBEnd:   4,
+		},
+	}
+
+	for _, tc := range testCases {
+		a := strdist.NewSmithWatermanAlgoOrPanic(tc.sc)
+
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("Dist(%q, %q)", tc.a, tc.b),
+			a.Dist(tc.a, tc.b), tc.expDist, 1e-12)
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("Dist(%q, %q)", tc.b, tc.a),
+			a.Dist(tc.b, tc.a), tc.expDist, 1e-12)
+
+		la := a.Alignment(tc.a, tc.b)
+		testhelper.DiffFloat(t, tc.IDStr(), "alignment Dist",
+			la.Dist, tc.expDist, 1e-12)
+		testhelper.DiffFloat(t, tc.IDStr(), "Score", la.Score, tc.expScore, 0)
+		testhelper.DiffString(t, tc.IDStr(), "markup",
+			la.Markup(strdist.BracketMarkup), tc.expMarkup)
+
+		if tc.expScore == 0 {
+			continue
+		}
+
+		testhelper.DiffInt(t, tc.IDStr(), "AStart", la.AStart, tc.expAStart)
+		testhelper.DiffInt(t, tc.IDStr(), "AEnd", la.AEnd, tc.expAEnd)
+		testhelper.DiffInt(t, tc.IDStr(), "BStart", la.BStart, tc.expBStart)
+		testhelper.DiffInt(t, tc.IDStr(), "BEnd", la.BEnd, tc.expBEnd)
+	}
+}
+
+func TestSmithWatermanFinder(t *testing.T) {
+	pop := []string{
+		"lazy dog",
+		"a brwn fox",
+		"foxes",
+		"the quick brown fox jumps",
+	}
+	f := strdist.DefaultFinders[strdist.AlgoNameSmithWaterman]
+	finderChecker(t, "Smith-Waterman", "default finder",
+		"brown fox", pop, f,
+		[]string{"the quick brown fox jumps", "a brwn fox"})
+}
+
+func TestSmithWatermanDistMatchesAlignment(t *testing.T) {
+	scores := map[string]strdist.AlignScores{
+		"default":   strdist.DfltAlignScores,
+		"linear":    {Match: 2, Mismatch: -1, GapOpen: 2, GapExtend: 2},
+		"free gaps": {Match: 1, Mismatch: -1},
+	}
+	pairs := [][2]string{
+		{"", "abc"},
+		{"abc", "abc"},
+		{"brwn fox", "the quick brown fox jumps"},
+		{"abcdef", "abcXYZdef"},
+		{"kitten", "sitting"},
+		{"§¶a", "ab§¶cd"},
+	}
+
+	for name, sc := range scores {
+		a := strdist.NewSmithWatermanAlgoOrPanic(sc)
+
+		for _, p := range pairs {
+			id := fmt.Sprintf("%s: %q, %q", name, p[0], p[1])
+			testhelper.DiffFloat(t, id, "Dist",
+				a.Dist(p[0], p[1]), a.Alignment(p[0], p[1]).Dist, 1e-12)
+		}
+	}
+}
+
+func TestSmithWatermanZeroValue(t *testing.T) {
+	a := strdist.SmithWatermanAlgo{}
+
+	testhelper.DiffFloat(t, "zero value", "Dist(same)",
+		a.Dist("abc", "abc"), 0, 0)
+	testhelper.DiffFloat(t, "zero value", "Dist(different)",
+		a.Dist("abc", "abd"), 1, 0)
+
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: strdist.DfltThresholdSmithWaterman}, a)
+	finderChecker(t, "zero value", "Finder",
+		"abc", []string{"abd", "xyz", "abc"}, f, []string{"abc"})
+}
@@ -1,6 +1,7 @@
 package strdist
 
 import (
+	"slices"
 	"sort"
 
 	"github.com/nickwells/english.mod/english"
@@ -19,11 +20,49 @@ func SuggestionString(vals []string) string {
 }
 
 // SuggestedVals returns a slice of suggested alternative values for
-// the given value
+// the given value. Alternatives which differ from the value by no more than
+// a single edit, as found by the case-blind keyboard Finder, are given
+// first. This prefers likely typing errors (keys pressed in the wrong order
+// or a neighbouring key pressed in error) and finds alternatives to short
+// values. Any remaining places are filled by the alternatives found by the
+// case-blind cosine Finder.
 func SuggestedVals(val string, alts []string) []string {
-	const alternativeCount = 3
+	const (
+		alternativeCount = 3
+		maxTypoDist      = 1.0
+	)
+
+	vals := make([]string, 0, alternativeCount)
+
+	kf := DefaultFinders[CaseBlindAlgoNameKeyboard]
+	for _, sd := range kf.FindLike(val, alts...) {
+		if sd.Dist > maxTypoDist || len(vals) == alternativeCount {
+			break
+		}
+
+		vals = append(vals, sd.Str)
+	}
+
+	cf := DefaultFinders[CaseBlindAlgoNameCosine]
+	for _, s := range cf.FindStrLike(val, alts...) {
+		if len(vals) == alternativeCount {
+			break
+		}
 
-	finder := DefaultFinders[CaseBlindAlgoNameCosine]
+		if !slices.Contains(vals, s) {
+			vals = append(vals, s)
+		}
+	}
+
+	return vals
+}
+
+// SuggestedValsWithFinder returns a slice of suggested alternative values
+// for the given value using the supplied Finder. For instance, using the
+// DefaultFinders entry for CaseBlindAlgoNameKeyboard will prefer
+// alternatives which differ by typing errors.
+func SuggestedValsWithFinder(f *Finder, val string, alts []string) []string {
+	const alternativeCount = 3
 
-	return finder.FindNStrLike(alternativeCount, val, alts...)
+	return f.FindNStrLike(alternativeCount, val, alts...)
 }
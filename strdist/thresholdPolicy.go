@@ -0,0 +1,258 @@
+package strdist
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// ThresholdPolicy gives the threshold to be used by a Finder for a given
+// target string. A fixed threshold is often a poor fit for targets of
+// differing lengths: a Levenshtein threshold that finds useful matches for
+// long strings will match almost anything for short ones. A
+// ThresholdPolicy allows the threshold to vary with the target.
+type ThresholdPolicy interface {
+	// Threshold returns the threshold to be used for the target string. The
+	// target will have been prepared according to the FinderConfig (mapped
+	// to lower case and so on) before being passed.
+	Threshold(target string) float64
+	// MaxThreshold returns the largest threshold that the policy can give
+	// and true or, if the threshold is unbounded, false. This is used to
+	// check that the policy is suitable for Normalized distances.
+	MaxThreshold() (float64, bool)
+	// Check returns a non-nil error if the policy is invalid
+	Check() error
+	// Desc returns a string describing the policy
+	Desc() string
+}
+
+// checkThreshold returns a non-nil error if the threshold is not finite and
+// >= 0. The name is used to describe the threshold in the error.
+func checkThreshold(name string, t float64) error {
+	if !(t >= 0) || math.IsInf(t, 1) {
+		return fmt.Errorf("the %s (%f) must be finite and >= 0", name, t)
+	}
+
+	return nil
+}
+
+// AbsoluteThreshold is a ThresholdPolicy giving the same threshold
+// whatever the target. This is the same as setting the FinderConfig
+// Threshold.
+type AbsoluteThreshold float64
+
+// Threshold returns the threshold value
+func (at AbsoluteThreshold) Threshold(_ string) float64 {
+	return float64(at)
+}
+
+// MaxThreshold returns the threshold value
+func (at AbsoluteThreshold) MaxThreshold() (float64, bool) {
+	return float64(at), true
+}
+
+// Check returns a non-nil error if the threshold is invalid
+func (at AbsoluteThreshold) Check() error {
+	return checkThreshold("threshold", float64(at))
+}
+
+// Desc returns a string describing the policy
+func (at AbsoluteThreshold) Desc() string {
+	return fmt.Sprintf("absolute: %g", float64(at))
+}
+
+// ProportionalThreshold is a ThresholdPolicy giving a threshold
+// proportional to the length (in runes) of the target. The threshold is
+// the Factor times the length but no less than the Min and, if the Max is
+// greater than zero, no more than the Max. So, for instance, a Factor of
+// 0.25 with a Levenshtein Algo allows one edit for every four characters.
+type ProportionalThreshold struct {
+	Factor float64
+	Min    float64
+	Max    float64
+}
+
+// Threshold returns the threshold for the target
+func (pt ProportionalThreshold) Threshold(target string) float64 {
+	t := max(pt.Factor*float64(utf8.RuneCountInString(target)), pt.Min)
+	if pt.Max > 0 {
+		t = min(t, pt.Max)
+	}
+
+	return t
+}
+
+// MaxThreshold returns the Max or, if the Factor is zero, the Min. The
+// threshold is unbounded if neither applies.
+func (pt ProportionalThreshold) MaxThreshold() (float64, bool) {
+	switch {
+	case pt.Max > 0:
+		return pt.Max, true
+	case pt.Factor == 0:
+		return pt.Min, true
+	}
+
+	return 0, false
+}
+
+// Check returns a non-nil error if the policy is invalid
+func (pt ProportionalThreshold) Check() error {
+	if err := checkThreshold("Factor", pt.Factor); err != nil {
+		return err
+	}
+
+	if err := checkThreshold("Min", pt.Min); err != nil {
+		return err
+	}
+
+	if err := checkThreshold("Max", pt.Max); err != nil {
+		return err
+	}
+
+	if pt.Max > 0 && pt.Max < pt.Min {
+		return fmt.Errorf("the Max (%f) must be >= the Min (%f)",
+			pt.Max, pt.Min)
+	}
+
+	return nil
+}
+
+// Desc returns a string describing the policy
+func (pt ProportionalThreshold) Desc() string {
+	s := fmt.Sprintf("proportional: Factor: %g Min: %g", pt.Factor, pt.Min)
+	if pt.Max > 0 {
+		s += fmt.Sprintf(" Max: %g", pt.Max)
+	}
+
+	return s
+}
+
+// ThresholdBand gives the threshold to be used for targets no longer (in
+// runes) than the MaxLen
+type ThresholdBand struct {
+	MaxLen    int
+	Threshold float64
+}
+
+// BandedThreshold is a ThresholdPolicy giving a threshold which is a step
+// function of the length (in runes) of the target. The threshold is that of
+// the first band whose MaxLen is no less than the length of the target. If
+// the target is longer than the MaxLen of every band then the Longer
+// threshold is used. The bands must be in increasing order of MaxLen.
+type BandedThreshold struct {
+	Bands  []ThresholdBand
+	Longer float64
+}
+
+// Threshold returns the threshold for the target
+func (bt BandedThreshold) Threshold(target string) float64 {
+	tLen := utf8.RuneCountInString(target)
+
+	for _, b := range bt.Bands {
+		if tLen <= b.MaxLen {
+			return b.Threshold
+		}
+	}
+
+	return bt.Longer
+}
+
+// MaxThreshold returns the largest of the band thresholds and the Longer
+// threshold
+func (bt BandedThreshold) MaxThreshold() (float64, bool) {
+	maxT := bt.Longer
+	for _, b := range bt.Bands {
+		maxT = max(maxT, b.Threshold)
+	}
+
+	return maxT, true
+}
+
+// Check returns a non-nil error if the policy is invalid
+func (bt BandedThreshold) Check() error {
+	if len(bt.Bands) == 0 {
+		return errors.New("there must be at least one band")
+	}
+
+	for i, b := range bt.Bands {
+		if b.MaxLen < 0 {
+			return fmt.Errorf("the MaxLen (%d) of band %d must be >= 0",
+				b.MaxLen, i)
+		}
+
+		if i > 0 && b.MaxLen <= bt.Bands[i-1].MaxLen {
+			return fmt.Errorf(
+				"the MaxLen (%d) of band %d must be > that of band %d (%d)",
+				b.MaxLen, i, i-1, bt.Bands[i-1].MaxLen)
+		}
+
+		if err := checkThreshold(fmt.Sprintf("Threshold of band %d", i),
+			b.Threshold); err != nil {
+			return err
+		}
+	}
+
+	return checkThreshold("Longer threshold", bt.Longer)
+}
+
+// Desc returns a string describing the policy
+func (bt BandedThreshold) Desc() string {
+	bands := make([]string, 0, len(bt.Bands))
+	for _, b := range bt.Bands {
+		bands = append(bands, fmt.Sprintf("<=%d: %g", b.MaxLen, b.Threshold))
+	}
+
+	return fmt.Sprintf("banded: %s, longer: %g",
+		strings.Join(bands, ", "), bt.Longer)
+}
+
+// FuncThreshold is a ThresholdPolicy giving the threshold returned by the
+// function, F, which must not be nil. The Name is used to describe the
+// policy. If F returns a negative value or NaN then the threshold is zero.
+// If the Max is greater than zero then the threshold is no more than the
+// Max; otherwise it is unbounded.
+type FuncThreshold struct {
+	Name string
+	F    func(target string) float64
+	Max  float64
+}
+
+// Threshold returns the threshold for the target
+func (ft FuncThreshold) Threshold(target string) float64 {
+	t := ft.F(target)
+	if !(t >= 0) {
+		t = 0
+	}
+
+	if ft.Max > 0 {
+		t = min(t, ft.Max)
+	}
+
+	return t
+}
+
+// MaxThreshold returns the Max, if it is set
+func (ft FuncThreshold) MaxThreshold() (float64, bool) {
+	return ft.Max, ft.Max > 0
+}
+
+// Check returns a non-nil error if the policy is invalid
+func (ft FuncThreshold) Check() error {
+	if ft.F == nil {
+		return errors.New("the threshold func must not be nil")
+	}
+
+	return checkThreshold("Max", ft.Max)
+}
+
+// Desc returns a string describing the policy
+func (ft FuncThreshold) Desc() string {
+	s := "func: " + ft.Name
+	if ft.Max > 0 {
+		s += fmt.Sprintf(" Max: %g", ft.Max)
+	}
+
+	return s
+}
@@ -0,0 +1,310 @@
+package strdist_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestThresholdPolicy(t *testing.T) {
+	banded := strdist.BandedThreshold{
+		Bands: []strdist.ThresholdBand{
+			{MaxLen: 4, Threshold: 0},
+			{MaxLen: 8, Threshold: 1},
+		},
+		Longer: 2,
+	}
+
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		tp         strdist.ThresholdPolicy
+		expDesc    string
+		expT       map[string]float64
+		expMax     float64
+		expBounded bool
+	}{
+		{
+			ID:         testhelper.MkID("absolute"),
+			tp:         strdist.AbsoluteThreshold(1.5),
+			expDesc:    "absolute: 1.5",
+			expT:       map[string]float64{"": 1.5, "abcdefghij": 1.5},
+			expMax:     1.5,
+			expBounded: true,
+		},
+		{
+			ID:     testhelper.MkID("absolute - negative"),
+			tp:     strdist.AbsoluteThreshold(-1),
+			ExpErr: testhelper.MkExpErr("the threshold (-1.000000) must be"),
+		},
+		{
+			ID:     testhelper.MkID("absolute - infinite"),
+			tp:     strdist.AbsoluteThreshold(math.Inf(1)),
+			ExpErr: testhelper.MkExpErr("must be finite and >= 0"),
+		},
+		{
+			ID: testhelper.MkID("proportional"),
+			tp: strdist.ProportionalThreshold{
+				Factor: 0.25,
+				Min:    1,
+				Max:    3,
+			},
+			expDesc: "proportional: Factor: 0.25 Min: 1 Max: 3",
+			expT: map[string]float64{
+				"abc":                  1,
+				"abcdefgh":             2,
+				"abçdéfghij":           2.5,
+				"abcdefghijklmnopqrst": 3,
+			},
+			expMax:     3,
+			expBounded: true,
+		},
+		{
+			ID:      testhelper.MkID("proportional - no max"),
+			tp:      strdist.ProportionalThreshold{Factor: 0.5},
+			expDesc: "proportional: Factor: 0.5 Min: 0",
+			expT: map[string]float64{
+				"":                     0,
+				"abcdefghijklmnopqrst": 10,
+			},
+		},
+		{
+			ID:         testhelper.MkID("proportional - no factor"),
+			tp:         strdist.ProportionalThreshold{Min: 0.5},
+			expDesc:    "proportional: Factor: 0 Min: 0.5",
+			expT:       map[string]float64{"abcdefghij": 0.5},
+			expMax:     0.5,
+			expBounded: true,
+		},
+		{
+			ID:     testhelper.MkID("proportional - bad factor"),
+			tp:     strdist.ProportionalThreshold{Factor: -0.5},
+			ExpErr: testhelper.MkExpErr("the Factor (-0.500000) must be"),
+		},
+		{
+			ID: testhelper.MkID("proportional - max < min"),
+			tp: strdist.ProportionalThreshold{Factor: 1, Min: 3, Max: 2},
+			ExpErr: testhelper.MkExpErr(
+				"the Max (2.000000) must be >= the Min (3.000000)"),
+		},
+		{
+			ID:      testhelper.MkID("banded"),
+			tp:      banded,
+			expDesc: "banded: <=4: 0, <=8: 1, longer: 2",
+			expT: map[string]float64{
+				"abcd":      0,
+				"abcde":     1,
+				"abcdefgh":  1,
+				"éééé":      0,
+				"abcdefghi": 2,
+			},
+			expMax:     2,
+			expBounded: true,
+		},
+		{
+			ID:     testhelper.MkID("banded - no bands"),
+			tp:     strdist.BandedThreshold{Longer: 2},
+			ExpErr: testhelper.MkExpErr("there must be at least one band"),
+		},
+		{
+			ID: testhelper.MkID("banded - bad order"),
+			tp: strdist.BandedThreshold{
+				Bands: []strdist.ThresholdBand{
+					{MaxLen: 4, Threshold: 0},
+					{MaxLen: 4, Threshold: 1},
+				},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"the MaxLen (4) of band 1 must be > that of band 0 (4)"),
+		},
+		{
+			ID: testhelper.MkID("banded - bad threshold"),
+			tp: strdist.BandedThreshold{
+				Bands: []strdist.ThresholdBand{
+					{MaxLen: 4, Threshold: -1},
+				},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"the Threshold of band 0 (-1.000000) must be"),
+		},
+		{
+			ID: testhelper.MkID("func"),
+			tp: strdist.FuncThreshold{
+				Name: "len/3",
+				F: func(s string) float64 {
+					return float64(len(s) / 3)
+				},
+			},
+			expDesc: "func: len/3",
+			expT:    map[string]float64{"ab": 0, "abcdefg": 2},
+		},
+		{
+			ID: testhelper.MkID("func - with max"),
+			tp: strdist.FuncThreshold{
+				Name: "len/3",
+				F: func(s string) float64 {
+					return float64(len(s) / 3)
+				},
+				Max: 1.5,
+			},
+			expDesc:    "func: len/3 Max: 1.5",
+			expT:       map[string]float64{"ab": 0, "abcdefg": 1.5},
+			expMax:     1.5,
+			expBounded: true,
+		},
+		{
+			ID: testhelper.MkID("func - bad values"),
+			tp: strdist.FuncThreshold{
+				Name: "bad",
+				F: func(s string) float64 {
+					if s == "nan" {
+						return math.NaN()
+					}
+
+					return -1
+				},
+			},
+			expDesc: "func: bad",
+			expT:    map[string]float64{"nan": 0, "negative": 0},
+		},
+		{
+			ID:     testhelper.MkID("func - nil"),
+			tp:     strdist.FuncThreshold{Name: "nil"},
+			ExpErr: testhelper.MkExpErr("the threshold func must not be nil"),
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.tp.Check()
+		if !testhelper.CheckExpErr(t, err, tc) || err != nil {
+			continue
+		}
+
+		testhelper.DiffString(t, tc.IDStr(), "Desc", tc.tp.Desc(), tc.expDesc)
+
+		maxT, bounded := tc.tp.MaxThreshold()
+		testhelper.DiffBool(t, tc.IDStr(), "bounded", bounded, tc.expBounded)
+		testhelper.DiffFloat(t, tc.IDStr(), "MaxThreshold", maxT, tc.expMax, 0)
+
+		for target, exp := range tc.expT {
+			testhelper.DiffFloat(t, tc.IDStr(), "threshold: "+target,
+				tc.tp.Threshold(target), exp, 0)
+		}
+	}
+}
+
+func TestFinderThresholdPolicy(t *testing.T) {
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{
+			Threshold: 10, // ignored in favour of the ThresholdPolicy
+			ThresholdPolicy: strdist.ProportionalThreshold{
+				Factor: 0.25,
+				Min:    1,
+			},
+			MinStrLength: 2,
+		},
+		strdist.LevenshteinAlgo{})
+
+	testCases := []struct {
+		testhelper.ID
+		s   string
+		pop []string
+		exp []string
+	}{
+		{
+			ID:  testhelper.MkID("short target"),
+			s:   "cat",
+			pop: []string{"cat", "cot", "dog", "coat", "cost"},
+			exp: []string{"cat", "cot", "coat"},
+		},
+		{
+			ID: testhelper.MkID("long target"),
+			s:  "internationalisation",
+			pop: []string{
+				"internationalization",
+				"internationalisations",
+				"intrenationalizatoin",
+				"international",
+			},
+			exp: []string{
+				"internationalization",
+				"internationalisations",
+				"intrenationalizatoin",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		finderChecker(t, tc.IDStr(), "threshold policy",
+			tc.s, tc.pop, f, tc.exp)
+	}
+}
+
+func TestNormalizedThresholdPolicy(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		fc strdist.FinderConfig
+	}{
+		{
+			ID: testhelper.MkID("bounded policy, Threshold ignored"),
+			fc: strdist.FinderConfig{
+				Threshold: 5,
+				ThresholdPolicy: strdist.ProportionalThreshold{
+					Factor: 0.05,
+					Max:    0.4,
+				},
+			},
+		},
+		{
+			ID: testhelper.MkID("absolute policy too large"),
+			fc: strdist.FinderConfig{
+				ThresholdPolicy: strdist.AbsoluteThreshold(5),
+			},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the ThresholdPolicy maximum threshold" +
+					" (5.000000) must be <= 1 when distances are Normalized"),
+		},
+		{
+			ID: testhelper.MkID("unbounded proportional policy"),
+			fc: strdist.FinderConfig{
+				ThresholdPolicy: strdist.ProportionalThreshold{Factor: 0.25},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the ThresholdPolicy" +
+					" (proportional: Factor: 0.25 Min: 0) must be bounded" +
+					" when distances are Normalized"),
+		},
+		{
+			ID: testhelper.MkID("unbounded func policy"),
+			fc: strdist.FinderConfig{
+				ThresholdPolicy: strdist.FuncThreshold{
+					Name: "half",
+					F:    func(_ string) float64 { return 0.5 },
+				},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: the ThresholdPolicy (func: half)" +
+					" must be bounded when distances are Normalized"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.fc.Normalized = true
+		_, err := strdist.NewFinder(tc.fc, strdist.LevenshteinAlgo{})
+		testhelper.CheckExpErr(t, err, tc)
+	}
+
+	// the Threshold is ignored when there is a ThresholdPolicy
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{
+			ThresholdPolicy: strdist.AbsoluteThreshold(0.25),
+			Normalized:      true,
+		},
+		strdist.LevenshteinAlgo{})
+	finderChecker(t, "Threshold ignored", "normalized threshold policy",
+		"colour", []string{"colour", "color", "collar", "cooler"}, f,
+		[]string{"colour", "color"})
+}
@@ -0,0 +1,242 @@
+package strdist
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// TieBreaker orders StrDists having the same distance from the target
+// string. The Finder sorts its results by distance and then by the
+// TieBreaker. The sort is stable so StrDists which the TieBreaker regards
+// as equal remain in population order.
+type TieBreaker interface {
+	// CmpFunc returns the function used to compare StrDists for the target
+	// string. The function returns a negative number if sd1 should come
+	// before sd2, a positive number if it should come after and zero if
+	// they are equal. A new function is requested for each sort so it may
+	// record details of the StrDists it has compared.
+	//
+	// The target will have been prepared according to the FinderConfig
+	// (mapped to lower case and so on) but the StrDist strings are as
+	// given in the population. The prep func prepares a string in the same
+	// way as the target. TieBreakers which compare the StrDist strings with
+	// the target, such as the AlgoTieBreaker, must prepare them first.
+	// Those which order the strings by properties of their own, such as the
+	// PriorityTieBreaker whose Priorities are keyed by the population
+	// strings as given, may ignore it.
+	CmpFunc(target string, prep func(string) string,
+	) func(sd1, sd2 StrDist) int
+	// Check returns a non-nil error if the TieBreaker is invalid
+	Check() error
+	// Desc returns a string describing the TieBreaker
+	Desc() string
+}
+
+// DefaultTieBreaker orders StrDists by the closeness of the length (in
+// runes) of their strings to that of the target and then lexically. This is
+// the TieBreaker used if none is given.
+type DefaultTieBreaker struct{}
+
+// CmpFunc returns a function comparing the StrDists by closeness in length
+// to the target string and then by the lexical ordering of their strings
+func (DefaultTieBreaker) CmpFunc(target string, _ func(string) string,
+) func(sd1, sd2 StrDist) int {
+	tLen := utf8.RuneCountInString(target)
+
+	return func(sd1, sd2 StrDist) int {
+		lenDiff1 := utf8.RuneCountInString(sd1.Str) - tLen
+		lenDiff2 := utf8.RuneCountInString(sd2.Str) - tLen
+
+		if c := cmp.Compare(lenDiff1*lenDiff1, lenDiff2*lenDiff2); c != 0 {
+			return c
+		}
+
+		return strings.Compare(sd1.Str, sd2.Str)
+	}
+}
+
+// Check returns a nil error
+func (DefaultTieBreaker) Check() error {
+	return nil
+}
+
+// Desc returns a string describing the TieBreaker
+func (DefaultTieBreaker) Desc() string {
+	return "length, lexical"
+}
+
+// PopulationOrderTieBreaker leaves StrDists with the same distance in the
+// order in which their strings appear in the population. This allows, for
+// instance, the most frequently used of a list of commands to be given
+// first.
+type PopulationOrderTieBreaker struct{}
+
+// CmpFunc returns a function regarding all StrDists as equal
+func (PopulationOrderTieBreaker) CmpFunc(_ string, _ func(string) string,
+) func(sd1, sd2 StrDist) int {
+	return func(_, _ StrDist) int { return 0 }
+}
+
+// Check returns a nil error
+func (PopulationOrderTieBreaker) Check() error {
+	return nil
+}
+
+// Desc returns a string describing the TieBreaker
+func (PopulationOrderTieBreaker) Desc() string {
+	return "population order"
+}
+
+// PriorityTieBreaker orders StrDists by the priority of their strings,
+// highest first. Strings not in the Priorities map have a priority of zero.
+// The priority might be, for instance, the frequency with which the
+// string has been used.
+type PriorityTieBreaker struct {
+	Priorities map[string]float64
+}
+
+// CmpFunc returns a function comparing the StrDists by the priorities of
+// their strings
+func (tb PriorityTieBreaker) CmpFunc(_ string, _ func(string) string,
+) func(sd1, sd2 StrDist) int {
+	return func(sd1, sd2 StrDist) int {
+		return cmp.Compare(tb.Priorities[sd2.Str], tb.Priorities[sd1.Str])
+	}
+}
+
+// Check returns a nil error
+func (PriorityTieBreaker) Check() error {
+	return nil
+}
+
+// Desc returns a string describing the TieBreaker
+func (PriorityTieBreaker) Desc() string {
+	return "priority"
+}
+
+// AlgoTieBreaker orders StrDists by the distance between the target and
+// their strings as given by a secondary Algo, closest first. The strings
+// are prepared in the same way as the target (mapped to lower case and so
+// on) before the distance is calculated.
+type AlgoTieBreaker struct {
+	Algo Algo
+}
+
+// CmpFunc returns a function comparing the StrDists by the distance of
+// their strings from the target as given by the Algo. The distance for each
+// string is only calculated once, when it is first compared.
+func (tb AlgoTieBreaker) CmpFunc(target string, prep func(string) string,
+) func(sd1, sd2 StrDist) int {
+	dists := map[string]float64{}
+
+	dist := func(s string) float64 {
+		d, ok := dists[s]
+		if !ok {
+			d = tb.Algo.Dist(target, prep(s))
+			dists[s] = d
+		}
+
+		return d
+	}
+
+	return func(sd1, sd2 StrDist) int {
+		return cmp.Compare(dist(sd1.Str), dist(sd2.Str))
+	}
+}
+
+// Check returns a non-nil error if the Algo is nil
+func (tb AlgoTieBreaker) Check() error {
+	if tb.Algo == nil {
+		return errors.New("the tie-break Algo must not be nil")
+	}
+
+	return nil
+}
+
+// Desc returns a string describing the TieBreaker
+func (tb AlgoTieBreaker) Desc() string {
+	return "algo: " + tb.Algo.Name()
+}
+
+// TieBreakerChain applies each of its TieBreakers in turn until one of them
+// distinguishes between the StrDists. For instance a PriorityTieBreaker
+// followed by the DefaultTieBreaker orders strings of equal priority by
+// length and then lexically.
+type TieBreakerChain []TieBreaker
+
+// CmpFunc returns a function giving the first non-zero comparison from the
+// TieBreakers
+func (tbc TieBreakerChain) CmpFunc(target string, prep func(string) string,
+) func(sd1, sd2 StrDist) int {
+	cmps := make([]func(sd1, sd2 StrDist) int, 0, len(tbc))
+	for _, tb := range tbc {
+		cmps = append(cmps, tb.CmpFunc(target, prep))
+	}
+
+	return func(sd1, sd2 StrDist) int {
+		for _, c := range cmps {
+			if rval := c(sd1, sd2); rval != 0 {
+				return rval
+			}
+		}
+
+		return 0
+	}
+}
+
+// Check returns a non-nil error if any of the TieBreakers is nil or invalid
+func (tbc TieBreakerChain) Check() error {
+	for i, tb := range tbc {
+		if tb == nil {
+			return fmt.Errorf("TieBreaker %d is nil", i)
+		}
+
+		if err := tb.Check(); err != nil {
+			return fmt.Errorf("TieBreaker %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Desc returns a string describing the TieBreaker
+func (tbc TieBreakerChain) Desc() string {
+	descs := make([]string, 0, len(tbc))
+	for _, tb := range tbc {
+		descs = append(descs, tb.Desc())
+	}
+
+	return strings.Join(descs, ", then ")
+}
+
+// cmpFunc returns a function that will compare the two StrDist values for
+// the (prepared) target string: firstly by the distance and then by the
+// TieBreaker (or the DefaultTieBreaker if none is set)
+func (fc FinderConfig) cmpFunc(target string) func(sd1, sd2 StrDist) int {
+	tb := fc.TieBreaker
+	if tb == nil {
+		tb = DefaultTieBreaker{}
+	}
+
+	tbCmp := tb.CmpFunc(target, fc.prepStr)
+
+	return func(sd1, sd2 StrDist) int {
+		if c := cmp.Compare(sd1.Dist, sd2.Dist); c != 0 {
+			return c
+		}
+
+		return tbCmp(sd1, sd2)
+	}
+}
+
+// CmpFunc returns the function used by the Finder to order the StrDists
+// found for the target string. This allows callers to sort StrDists (using
+// slices.SortStableFunc) in the same order as the Finder. The TieBreaker
+// may record details of the StrDists compared so the function should not be
+// shared between goroutines.
+func (fc FinderConfig) CmpFunc(target string) func(sd1, sd2 StrDist) int {
+	return fc.cmpFunc(fc.prepStr(target))
+}
@@ -0,0 +1,173 @@
+package strdist_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestTieBreaker(t *testing.T) {
+	pop := []string{"scat", "cot", "cart", "bat", "at", "cap"}
+
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		tb      strdist.TieBreaker
+		expDesc string
+		exp     []string
+	}{
+		{
+			ID:      testhelper.MkID("none"),
+			expDesc: "length, lexical",
+			exp:     []string{"bat", "cap", "cot", "at", "cart", "scat"},
+		},
+		{
+			ID:      testhelper.MkID("default"),
+			tb:      strdist.DefaultTieBreaker{},
+			expDesc: "length, lexical",
+			exp:     []string{"bat", "cap", "cot", "at", "cart", "scat"},
+		},
+		{
+			ID:      testhelper.MkID("population order"),
+			tb:      strdist.PopulationOrderTieBreaker{},
+			expDesc: "population order",
+			exp:     []string{"scat", "cot", "cart", "bat", "at", "cap"},
+		},
+		{
+			ID: testhelper.MkID("priority"),
+			tb: strdist.PriorityTieBreaker{
+				Priorities: map[string]float64{"cart": 10, "cot": 2},
+			},
+			expDesc: "priority",
+			exp:     []string{"cart", "cot", "scat", "bat", "at", "cap"},
+		},
+		{
+			ID:      testhelper.MkID("algo"),
+			tb:      strdist.AlgoTieBreaker{Algo: strdist.LCSAlgo{}},
+			expDesc: "algo: LCS",
+			exp:     []string{"scat", "cart", "at", "cot", "bat", "cap"},
+		},
+		{
+			ID: testhelper.MkID("chain"),
+			tb: strdist.TieBreakerChain{
+				strdist.PriorityTieBreaker{
+					Priorities: map[string]float64{"at": 1, "cot": 1},
+				},
+				strdist.DefaultTieBreaker{},
+			},
+			expDesc: "priority, then length, lexical",
+			exp:     []string{"cot", "at", "bat", "cap", "cart", "scat"},
+		},
+		{
+			ID:     testhelper.MkID("algo - nil"),
+			tb:     strdist.AlgoTieBreaker{},
+			ExpErr: testhelper.MkExpErr("the tie-break Algo must not be nil"),
+		},
+		{
+			ID: testhelper.MkID("chain - nil entry"),
+			tb: strdist.TieBreakerChain{
+				strdist.DefaultTieBreaker{},
+				nil,
+			},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: bad TieBreaker: TieBreaker 1 is nil"),
+		},
+		{
+			ID: testhelper.MkID("chain - bad entry"),
+			tb: strdist.TieBreakerChain{
+				strdist.AlgoTieBreaker{},
+			},
+			ExpErr: testhelper.MkExpErr(
+				"FinderConfig: bad TieBreaker:" +
+					" TieBreaker 0: the tie-break Algo must not be nil"),
+		},
+	}
+
+	for _, tc := range testCases {
+		fc := strdist.FinderConfig{
+			Threshold:    1,
+			MinStrLength: 2,
+			TieBreaker:   tc.tb,
+		}
+
+		f, err := strdist.NewFinder(fc, strdist.LevenshteinAlgo{})
+		if !testhelper.CheckExpErr(t, err, tc) || err != nil {
+			continue
+		}
+
+		if tc.tb != nil {
+			testhelper.DiffString(t, tc.IDStr(), "Desc",
+				tc.tb.Desc(), tc.expDesc)
+		}
+
+		finderChecker(t, tc.IDStr(), "tie-break", "cat", pop, f, tc.exp)
+	}
+}
+
+func TestCmpFunc(t *testing.T) {
+	fc := strdist.FinderConfig{
+		Threshold:      2,
+		MapToLowerCase: true,
+	}
+	f := strdist.NewFinderOrPanic(fc, strdist.LevenshteinAlgo{})
+	pop := []string{"Colour", "colours", "color", "cooler", "dollar"}
+
+	found := f.FindLike("COLOUR", pop...)
+
+	sds := slices.Clone(found)
+	slices.Reverse(sds)
+	slices.SortStableFunc(sds, fc.CmpFunc("COLOUR"))
+
+	testhelper.DiffSlice(t, "CmpFunc", "sorted StrDists", sds, found)
+}
+
+func TestDefaultTieBreakerRunes(t *testing.T) {
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{Threshold: 1, MinStrLength: 2},
+		strdist.LevenshteinAlgo{})
+
+	// "ébc" has the same number of runes as the target but more bytes so it
+	// comes before "abcd" which is lexically earlier
+	finderChecker(t, "multi-byte", "default tie-break",
+		"abc", []string{"abcd", "ébc"}, f, []string{"ébc", "abcd"})
+}
+
+// countingAlgo wraps an Algo and records the strings whose distance from the
+// target it has been asked for
+type countingAlgo struct {
+	strdist.Algo
+	calls map[string]int
+}
+
+func (ca countingAlgo) Dist(s1, s2 string) float64 {
+	ca.calls[s2]++
+
+	return ca.Algo.Dist(s1, s2)
+}
+
+func TestAlgoTieBreakerPrep(t *testing.T) {
+	ca := countingAlgo{Algo: strdist.LCSAlgo{}, calls: map[string]int{}}
+	f := strdist.NewFinderOrPanic(
+		strdist.FinderConfig{
+			Threshold:      1,
+			MapToLowerCase: true,
+			TieBreaker:     strdist.AlgoTieBreaker{Algo: ca},
+		},
+		strdist.LevenshteinAlgo{})
+
+	// "CAB" is as close to "cat" as "cot" once it is mapped to lower case so
+	// it stays first; were it compared unmapped it would come last
+	finderChecker(t, "mapped to lower case", "algo tie-break",
+		"cat", []string{"CAB", "cot", "bat", "hat"}, f,
+		[]string{"CAB", "cot", "bat", "hat"})
+
+	testhelper.DiffInt(t, "mapped to lower case", "Dist calls",
+		len(ca.calls), 4)
+
+	for s, n := range ca.calls {
+		testhelper.DiffInt(t, "mapped to lower case", "Dist calls for "+s,
+			n, 1)
+	}
+}
@@ -0,0 +1,191 @@
+package strdist
+
+import (
+	"slices"
+	"strings"
+)
+
+// Tokeniser splits a string into tokens (typically words)
+type Tokeniser func(s string) []string
+
+// DfltTokeniser is the Tokeniser used if none is given. It splits the string
+// on white space.
+var DfltTokeniser Tokeniser = strings.Fields
+
+// algoDesc returns a string describing the algorithm, giving both the name
+// and the description (if any)
+func algoDesc(a Algo) string {
+	if d := a.Desc(); d != "" {
+		return a.Name() + " (" + d + ")"
+	}
+
+	return a.Name()
+}
+
+// TokenSortAlgo encapsulates the details needed to provide the token sort
+// distance. This splits each string into tokens which are then sorted and
+// joined back together, separated by a single space, before being compared
+// using the inner Algo. This allows strings whose words have been reordered
+// to be recognised as similar (for instance, "New York Mets" and "Mets New
+// York" will be identical).
+type TokenSortAlgo struct {
+	tok   Tokeniser
+	inner Algo
+}
+
+// NewTokenSortAlgo returns a new TokenSortAlgo. If the tokeniser is nil then
+// the DfltTokeniser is used and if the inner Algo is nil then a
+// ScaledLevAlgo is used.
+func NewTokenSortAlgo(tok Tokeniser, inner Algo) *TokenSortAlgo {
+	if tok == nil {
+		tok = DfltTokeniser
+	}
+
+	if inner == nil {
+		inner = ScaledLevAlgo{}
+	}
+
+	return &TokenSortAlgo{
+		tok:   tok,
+		inner: inner,
+	}
+}
+
+// Name returns the algorithm name
+func (TokenSortAlgo) Name() string {
+	return AlgoNameTokenSort
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a TokenSortAlgo) Desc() string {
+	return "Inner: " + algoDesc(a.inner)
+}
+
+// Dist for a TokenSortAlgo will calculate the distance between the two
+// strings after their tokens have been sorted
+func (a TokenSortAlgo) Dist(s1, s2 string) float64 {
+	return a.inner.Dist(sortedTokens(a.tok, s1), sortedTokens(a.tok, s2))
+}
+
+// NormalizedDist for a TokenSortAlgo will calculate the normalised distance,
+// as given by the inner Algo, between the two strings after their tokens
+// have been sorted
+func (a TokenSortAlgo) NormalizedDist(s1, s2 string) float64 {
+	return normalizedDistFunc(a.inner)(
+		sortedTokens(a.tok, s1), sortedTokens(a.tok, s2))
+}
+
+// sortedTokens splits the string into tokens, sorts them and joins them back
+// together
+func sortedTokens(tok Tokeniser, s string) string {
+	tokens := tok(s)
+	slices.Sort(tokens)
+
+	return strings.Join(tokens, " ")
+}
+
+// TokenSetAlgo encapsulates the details needed to provide the token set
+// distance. This splits each string into a set of tokens and then forms
+// three strings: the sorted intersection of the two sets, and that same
+// intersection followed by the sorted tokens only found in the first
+// (respectively second) string. The distance is the smallest distance,
+// calculated using the inner Algo, between any pair of these three
+// strings. This means that strings where one has all the tokens of the
+// other will be found to be identical.
+type TokenSetAlgo struct {
+	tok   Tokeniser
+	inner Algo
+}
+
+// NewTokenSetAlgo returns a new TokenSetAlgo. If the tokeniser is nil then
+// the DfltTokeniser is used and if the inner Algo is nil then a
+// ScaledLevAlgo is used.
+func NewTokenSetAlgo(tok Tokeniser, inner Algo) *TokenSetAlgo {
+	if tok == nil {
+		tok = DfltTokeniser
+	}
+
+	if inner == nil {
+		inner = ScaledLevAlgo{}
+	}
+
+	return &TokenSetAlgo{
+		tok:   tok,
+		inner: inner,
+	}
+}
+
+// Name returns the algorithm name
+func (TokenSetAlgo) Name() string {
+	return AlgoNameTokenSet
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a TokenSetAlgo) Desc() string {
+	return "Inner: " + algoDesc(a.inner)
+}
+
+// Dist for a TokenSetAlgo will calculate the smallest distance between the
+// strings constructed from the intersection and differences of the sets of
+// tokens
+func (a TokenSetAlgo) Dist(s1, s2 string) float64 {
+	return a.dist(s1, s2, a.inner.Dist)
+}
+
+// NormalizedDist for a TokenSetAlgo is as for Dist but uses the normalised
+// distances given by the inner Algo
+func (a TokenSetAlgo) NormalizedDist(s1, s2 string) float64 {
+	return a.dist(s1, s2, normalizedDistFunc(a.inner))
+}
+
+// dist calculates the distance using the given func to calculate the
+// inner distances
+func (a TokenSetAlgo) dist(s1, s2 string, innerDist distFunc) float64 {
+	tokens1 := tokenSet(a.tok, s1)
+	tokens2 := tokenSet(a.tok, s2)
+
+	var both, only1, only2 []string
+
+	for t := range tokens1 {
+		if _, ok := tokens2[t]; ok {
+			both = append(both, t)
+		} else {
+			only1 = append(only1, t)
+		}
+	}
+
+	for t := range tokens2 {
+		if _, ok := tokens1[t]; !ok {
+			only2 = append(only2, t)
+		}
+	}
+
+	slices.Sort(both)
+	slices.Sort(only1)
+	slices.Sort(only2)
+
+	sect := strings.Join(both, " ")
+	combined1 := strings.Join(append(slices.Clone(both), only1...), " ")
+	combined2 := strings.Join(append(slices.Clone(both), only2...), " ")
+
+	// with no tokens in common the intersection tells us nothing and
+	// comparing against it would make an empty string identical to any other
+	if len(both) == 0 {
+		return innerDist(combined1, combined2)
+	}
+
+	return min(
+		innerDist(sect, combined1),
+		innerDist(sect, combined2),
+		innerDist(combined1, combined2))
+}
+
+// tokenSet splits the string into tokens and returns them as a set
+func tokenSet(tok Tokeniser, s string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, t := range tok(s) {
+		set[t] = struct{}{}
+	}
+
+	return set
+}
@@ -0,0 +1,183 @@
+package strdist_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestTokenSort(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b    string
+		expDist float64
+	}{
+		{
+			ID:      testhelper.MkID("both empty"),
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("one empty"),
+			a:       "abc",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("reordered"),
+			a:       "New York Mets",
+			b:       "Mets New York",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("reordered, extra spaces"),
+			a:       "new york mets vs  atlanta braves",
+			b:       " atlanta braves vs new york mets",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("extra word"),
+			a:       "fuzzy was a bear",
+			b:       "fuzzy fuzzy was a bear",
+			expDist: 6.0 / 22.0,
+		},
+	}
+
+	a := strdist.NewTokenSortAlgo(nil, nil)
+
+	for _, tc := range testCases {
+		const epsilon = 0.00001
+
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("TokenSortAlgo.Dist(%q, %q)", tc.a, tc.b),
+			a.Dist(tc.a, tc.b), tc.expDist, epsilon)
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("TokenSortAlgo.Dist(%q, %q)", tc.b, tc.a),
+			a.Dist(tc.b, tc.a), tc.expDist, epsilon)
+	}
+}
+
+func TestTokenSet(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		a, b    string
+		expDist float64
+	}{
+		{
+			ID:      testhelper.MkID("both empty"),
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("one empty"),
+			a:       "abc",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("nothing in common"),
+			a:       "abc",
+			b:       "xyz",
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("subset"),
+			a:       "mariners vs angels",
+			b:       "los angeles angels vs seattle mariners",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("repeated word"),
+			a:       "fuzzy was a bear",
+			b:       "fuzzy fuzzy was a bear",
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("differing words"),
+			a:       "new york mets",
+			b:       "new york yankees",
+			expDist: 5.0 / 16.0,
+		},
+	}
+
+	a := strdist.NewTokenSetAlgo(nil, nil)
+
+	for _, tc := range testCases {
+		const epsilon = 0.00001
+
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("TokenSetAlgo.Dist(%q, %q)", tc.a, tc.b),
+			a.Dist(tc.a, tc.b), tc.expDist, epsilon)
+		testhelper.DiffFloat(t, tc.IDStr(),
+			fmt.Sprintf("TokenSetAlgo.Dist(%q, %q)", tc.b, tc.a),
+			a.Dist(tc.b, tc.a), tc.expDist, epsilon)
+	}
+}
+
+func TestTokenAlgoConfig(t *testing.T) {
+	commaTok := func(s string) []string { return strings.Split(s, ",") }
+	testCases := []struct {
+		testhelper.ID
+		algo    strdist.Algo
+		a, b    string
+		expName string
+		expDesc string
+		expDist float64
+	}{
+		{
+			ID:      testhelper.MkID("token sort: defaults"),
+			algo:    strdist.NewTokenSortAlgo(nil, nil),
+			a:       "a,b",
+			b:       "b,a",
+			expName: strdist.AlgoNameTokenSort,
+			expDesc: "Inner: " + strdist.AlgoNameScaledLevenshtein,
+			expDist: 2.0 / 3.0,
+		},
+		{
+			ID:      testhelper.MkID("token sort: comma tokeniser"),
+			algo:    strdist.NewTokenSortAlgo(commaTok, strdist.LevenshteinAlgo{}),
+			a:       "a,b",
+			b:       "b,a",
+			expName: strdist.AlgoNameTokenSort,
+			expDesc: "Inner: " + strdist.AlgoNameLevenshtein,
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("token set: comma tokeniser"),
+			algo:    strdist.NewTokenSetAlgo(commaTok, strdist.LevenshteinAlgo{}),
+			a:       "a,b,c",
+			b:       "c,a",
+			expName: strdist.AlgoNameTokenSet,
+			expDesc: "Inner: " + strdist.AlgoNameLevenshtein,
+			expDist: 0,
+		},
+		{
+			ID: testhelper.MkID("partial token sort"),
+			algo: strdist.NewTokenSortAlgo(nil,
+				strdist.NewPartialAlgo(nil)),
+			a:       "new mets",
+			b:       "new york mets",
+			expName: strdist.AlgoNameTokenSort,
+			expDesc: "Inner: " + strdist.AlgoNamePartial +
+				" (Inner: " + strdist.AlgoNameScaledLevenshtein + ")",
+			expDist: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffString(t, tc.IDStr(), "Name", tc.algo.Name(), tc.expName)
+		testhelper.DiffString(t, tc.IDStr(), "Desc", tc.algo.Desc(), tc.expDesc)
+		testhelper.DiffFloat(t, tc.IDStr(), "Dist",
+			tc.algo.Dist(tc.a, tc.b), tc.expDist, 0.00001)
+	}
+}
+
+func TestTokenSortFinder(t *testing.T) {
+	f := strdist.DefaultFinders[strdist.CaseBlindAlgoNameTokenSort]
+	pop := []string{"Mets New York", "New York Yankees", "Boston Red Sox"}
+	finderChecker(t, "case-blind token sort", "default finder",
+		"new york mets", pop, f, []string{"Mets New York"})
+
+	f = strdist.DefaultFinders[strdist.CaseBlindAlgoNameTokenSet]
+	finderChecker(t, "case-blind token set", "default finder",
+		"york", pop, f, []string{"Mets New York", "New York Yankees"})
+}
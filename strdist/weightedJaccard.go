@@ -50,6 +50,18 @@ func (a *WeightedJaccardAlgo) Dist(s1, s2 string) float64 {
 	return 1.0 - WeightedJaccardIndex(ngs1, ngs2)
 }
 
+// NormalizedDist for a WeightedJaccardAlgo rescales the distance. The
+// weighted union counts the n-grams of both strings so the index is at most
+// 0.5 (for identical strings) and Dist is never less than 0.5; this maps
+// the index onto the range [0,1] so that identical strings have a distance
+// of 0.
+func (a *WeightedJaccardAlgo) NormalizedDist(s1, s2 string) float64 {
+	ngs1 := a.getNGramSet(s1)
+	ngs2 := a.getNGramSet(s2)
+
+	return clamp01(1.0 - 2.0*WeightedJaccardIndex(ngs1, ngs2))
+}
+
 // WeightedJaccardIndex returns the Weighted Jaccard index of the two n-gram
 // sets. It uses the NGramWeightedLen... functions to calculate the length
 func WeightedJaccardIndex(ngs1, ngs2 NGramSet) float64 {
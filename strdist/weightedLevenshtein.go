@@ -0,0 +1,190 @@
+package strdist
+
+import (
+	"errors"
+	"math"
+)
+
+// WeightedLevenshteinAlgo encapsulates the details needed to provide the
+// weighted Levenshtein distance. This is an edit distance where the cost of
+// each edit operation (insertion, deletion, substitution and transposition
+// of adjacent runes) is given by a CostModel.
+type WeightedLevenshteinAlgo struct {
+	cm CostModel
+}
+
+// NewWeightedLevenshteinAlgo returns a new WeightedLevenshteinAlgo with the
+// cost model set. The cost model must not be nil and must be valid.
+func NewWeightedLevenshteinAlgo(cm CostModel) (
+	*WeightedLevenshteinAlgo, error,
+) {
+	if cm == nil {
+		return nil, errors.New("the CostModel must not be nil")
+	}
+
+	if err := cm.Check(); err != nil {
+		return nil, err
+	}
+
+	return &WeightedLevenshteinAlgo{cm: cm}, nil
+}
+
+// NewWeightedLevenshteinAlgoOrPanic returns a new WeightedLevenshteinAlgo. It
+// will panic if the algo cannot be created without errors.
+func NewWeightedLevenshteinAlgoOrPanic(cm CostModel,
+) *WeightedLevenshteinAlgo {
+	a, err := NewWeightedLevenshteinAlgo(cm)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// Name returns the algorithm name
+func (WeightedLevenshteinAlgo) Name() string {
+	return AlgoNameWeightedLevenshtein
+}
+
+// Desc returns a string describing the algorithm configuration
+func (a WeightedLevenshteinAlgo) Desc() string {
+	return a.cm.Desc()
+}
+
+// Dist for a WeightedLevenshteinAlgo will calculate the weighted Levenshtein
+// distance between the two strings
+func (a WeightedLevenshteinAlgo) Dist(s1, s2 string) float64 {
+	return WeightedLevenshteinDistance(s1, s2, a.cm)
+}
+
+// NormalizedDist for a WeightedLevenshteinAlgo gives the weighted
+// Levenshtein distance divided by the cost of a simple way of transforming
+// the first string into the second: the runes at the same position in both
+// strings are substituted (or deleted and inserted if that is cheaper) and
+// the remaining runes of the longer string are deleted or inserted. Runes
+// which are the same are costed as the cheaper of deleting or inserting
+// the rune as a CostModel gives no cost for substituting a rune with
+// itself. This is an upper bound on the distance and with LevenshteinCosts
+// it is the length of the longer string so the result is the same as the
+// ScaledLevDistance. If that cost is infinite the distance, d, is
+// normalised as d/(1+d).
+func (a WeightedLevenshteinAlgo) NormalizedDist(s1, s2 string) float64 {
+	d := a.Dist(s1, s2)
+	if d == 0 {
+		return 0
+	}
+
+	maxCost := a.simplePathCost([]rune(s1), []rune(s2))
+	if math.IsInf(maxCost, 1) {
+		return clamp01(d / (1 + d))
+	}
+
+	return clamp01(d / maxCost)
+}
+
+// simplePathCost returns the cost of the simple transformation of ra into rb
+// described for NormalizedDist
+func (a WeightedLevenshteinAlgo) simplePathCost(ra, rb []rune) float64 {
+	var cost float64
+
+	for i := range min(len(ra), len(rb)) {
+		delCost, insCost := a.cm.DelCost(ra[i]), a.cm.InsCost(rb[i])
+
+		if ra[i] == rb[i] {
+			cost += min(delCost, insCost)
+		} else {
+			cost += min(a.cm.SubCost(ra[i], rb[i]), delCost+insCost)
+		}
+	}
+
+	for _, r := range ra[min(len(ra), len(rb)):] {
+		cost += a.cm.DelCost(r)
+	}
+
+	for _, r := range rb[min(len(ra), len(rb)):] {
+		cost += a.cm.InsCost(r)
+	}
+
+	return cost
+}
+
+// WeightedLevenshteinDistance calculates the weighted Levenshtein distance
+// between strings a and b using the costs given by the CostModel. This is
+// the lowest total cost of any sequence of edit operations transforming a
+// into b. Transpositions are treated as for the optimal string alignment
+// distance so no substring can be edited more than once. If there is no
+// permitted way to transform a into b (because the needed operations have
+// infinite cost) then the distance will be +Inf.
+func WeightedLevenshteinDistance(a, b string, cm CostModel) float64 {
+	ra, rb := []rune(a), []rune(b)
+	_, last := weightedLevRows(ra, rb, cm, false)
+
+	return last[len(rb)]
+}
+
+// weightedLevRows calculates the table of weighted Levenshtein distances
+// between the prefixes of a and b and returns the last two rows. The last
+// row gives the distances between a and each prefix of b and the previous
+// row (which is nil if a is empty) gives the distances between a without
+// its last rune and each prefix of b. Only three rows of the table are kept
+// (the row before the previous row is needed for transpositions) and so it
+// uses memory proportional to the length of b.
+//
+// If reversed is true then a and b are taken to be the reverse of the
+// strings being compared. This only affects the order of the runes passed to
+// the TransCost method of the CostModel.
+func weightedLevRows(a, b []rune, cm CostModel, reversed bool) (
+	prev, last []float64,
+) {
+	prev2 := make([]float64, len(b)+1)
+	prev = make([]float64, len(b)+1)
+	curr := make([]float64, len(b)+1)
+
+	for j, bRune := range b {
+		prev[j+1] = prev[j] + cm.InsCost(bRune)
+	}
+
+	for i, aRune := range a {
+		curr[0] = prev[0] + cm.DelCost(aRune)
+
+		for j, bRune := range b {
+			sub := prev[j]
+			if aRune != bRune {
+				sub += cm.SubCost(aRune, bRune)
+			}
+
+			d := min(
+				prev[j+1]+cm.DelCost(aRune),
+				curr[j]+cm.InsCost(bRune),
+				sub)
+
+			if isTransposition(a, b, i, j) {
+				tc := cm.TransCost(a[i-1], aRune)
+				if reversed {
+					tc = cm.TransCost(aRune, a[i-1])
+				}
+
+				d = min(d, prev2[j-1]+tc)
+			}
+
+			curr[j+1] = d
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	if len(a) == 0 {
+		return nil, prev
+	}
+
+	return prev2, prev
+}
+
+// isTransposition returns true if the runes a[i-1], a[i] are the same as the
+// runes b[j], b[j-1] and are not the same as each other
+func isTransposition(a, b []rune, i, j int) bool {
+	return i > 0 && j > 0 &&
+		a[i] != a[i-1] &&
+		a[i] == b[j-1] &&
+		a[i-1] == b[j]
+}
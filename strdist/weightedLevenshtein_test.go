@@ -0,0 +1,156 @@
+package strdist_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/nickwells/strdist.mod/v2/strdist"
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+)
+
+func TestWeightedLevenshtein(t *testing.T) {
+	insExpensive := strdist.OpCosts{Ins: 2, Del: 1, Sub: 5, Trans: 1}
+	noIns := strdist.OpCosts{Ins: math.Inf(1), Del: 1, Sub: 1, Trans: 1}
+
+	testCases := []struct {
+		testhelper.ID
+		a, b    string
+		cm      strdist.CostModel
+		expDist float64
+	}{
+		{
+			ID:      testhelper.MkID("Levenshtein: both empty"),
+			cm:      strdist.LevenshteinCosts,
+			expDist: 0,
+		},
+		{
+			ID:      testhelper.MkID("Levenshtein: Kitten/Sitting"),
+			a:       "Kitten",
+			b:       "Sitting",
+			cm:      strdist.LevenshteinCosts,
+			expDist: 3,
+		},
+		{
+			ID:      testhelper.MkID("Levenshtein: transposed"),
+			a:       "git",
+			b:       "gti",
+			cm:      strdist.LevenshteinCosts,
+			expDist: 2,
+		},
+		{
+			ID:      testhelper.MkID("Damerau: transposed"),
+			a:       "git",
+			b:       "gti",
+			cm:      strdist.DamerauLevenshteinCosts,
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("Damerau: OSA limit"),
+			a:       "ca",
+			b:       "abc",
+			cm:      strdist.DamerauLevenshteinCosts,
+			expDist: 3,
+		},
+		{
+			ID:      testhelper.MkID("Damerau: multi-byte runes"),
+			a:       "a§¶b",
+			b:       "a¶§b",
+			cm:      strdist.DamerauLevenshteinCosts,
+			expDist: 1,
+		},
+		{
+			ID:      testhelper.MkID("OCR"),
+			a:       "B00K",
+			b:       "BOOK",
+			cm:      strdist.OCRCosts,
+			expDist: 0.5,
+		},
+		{
+			ID:      testhelper.MkID("OCR: not a confusion"),
+			a:       "B00K",
+			b:       "BXXK",
+			cm:      strdist.OCRCosts,
+			expDist: 2,
+		},
+		{
+			ID:      testhelper.MkID("expensive insertion: delete"),
+			a:       "abc",
+			cm:      insExpensive,
+			expDist: 3,
+		},
+		{
+			ID:      testhelper.MkID("expensive insertion: insert"),
+			b:       "abc",
+			cm:      insExpensive,
+			expDist: 6,
+		},
+		{
+			ID:      testhelper.MkID("expensive substitution"),
+			a:       "a",
+			b:       "b",
+			cm:      insExpensive,
+			expDist: 3,
+		},
+		{
+			ID:      testhelper.MkID("no insertion allowed"),
+			a:       "a",
+			b:       "ab",
+			cm:      noIns,
+			expDist: math.Inf(1),
+		},
+	}
+
+	for _, tc := range testCases {
+		const epsilon = 0.00001
+
+		id := tc.IDStr() +
+			fmt.Sprintf(" - WeightedLevenshteinDistance(%q, %q)", tc.a, tc.b)
+
+		dist := strdist.WeightedLevenshteinDistance(tc.a, tc.b, tc.cm)
+		if math.IsInf(tc.expDist, 1) {
+			if !math.IsInf(dist, 1) {
+				t.Log(id)
+				t.Errorf("\t: expected +Inf, got: %g\n", dist)
+			}
+
+			continue
+		}
+
+		testhelper.DiffFloat(t, id, "distance", dist, tc.expDist, epsilon)
+
+		a := strdist.NewWeightedLevenshteinAlgoOrPanic(tc.cm)
+		testhelper.DiffFloat(t, id, "Algo distance",
+			a.Dist(tc.a, tc.b), tc.expDist, epsilon)
+	}
+}
+
+func TestNewWeightedLevenshteinAlgo(t *testing.T) {
+	_, err := strdist.NewWeightedLevenshteinAlgo(nil)
+	testhelper.CheckExpErrWithID(t, "nil cost model", err,
+		testhelper.MkExpErr("the CostModel must not be nil"))
+
+	a, err := strdist.NewWeightedLevenshteinAlgo(strdist.OCRCosts)
+	if err != nil {
+		t.Fatal("unexpected error creating the WeightedLevenshteinAlgo:", err)
+	}
+
+	testhelper.DiffString(t, "OCR costs", "Name",
+		a.Name(), strdist.AlgoNameWeightedLevenshtein)
+	testhelper.DiffString(t, "OCR costs", "Desc",
+		a.Desc(), "Ins: 1 Del: 1 Sub: 1 Trans: +Inf SubTable: 30 entries")
+}
+
+func TestWeightedLevenshteinFinder(t *testing.T) {
+	pop := []string{"from", "Form", "forum", "fame", "qwertyuiop"}
+
+	f := strdist.DefaultFinders[strdist.AlgoNameWeightedLevenshtein]
+	finderChecker(t, "weighted Levenshtein", "default finder",
+		"form", pop, f,
+		[]string{"Form", "from", "forum", "fame"})
+
+	f = strdist.DefaultFinders[strdist.CaseBlindAlgoNameWeightedLevenshtein]
+	finderChecker(t, "case-blind weighted Levenshtein", "default finder",
+		"form", pop, f,
+		[]string{"Form", "from", "forum", "fame"})
+}